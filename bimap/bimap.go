@@ -0,0 +1,83 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package bimap provides BiTree, an ordered one-to-one mapping that can be
+// looked up, iterated and range-queried by key as well as by value.
+package bimap
+
+import (
+	"cmp"
+
+	"github.com/alexandremahdhaoui/llrb"
+)
+
+// ------------------------------------------------------------------------------
+// -- BITREE
+//
+// A BiTree couples two llrb trees, key->value and value->key, and keeps them
+// consistent on every mutation: each key maps to exactly one value and each
+// value to exactly one key. The zero value is an empty BiTree ready to use.
+// ------------------------------------------------------------------------------
+
+type BiTree[K, V cmp.Ordered] struct {
+	forward llrb.Tree[K, V]
+	inverse llrb.Tree[V, K]
+}
+
+// Search returns the value mapped to key.
+func (b *BiTree[K, V]) Search(key K) (V, bool) {
+	return b.forward.Search(key)
+}
+
+// SearchByValue returns the key mapped to value.
+func (b *BiTree[K, V]) SearchByValue(value V) (K, bool) {
+	return b.inverse.Search(value)
+}
+
+// Insert maps key to value. Any previous mapping of key, and any previous
+// mapping to value, is removed first so that the pairing stays one-to-one.
+func (b *BiTree[K, V]) Insert(key K, value V) {
+	b.Delete(key)
+	b.DeleteByValue(value)
+
+	b.forward.Insert(key, value)
+	b.inverse.Insert(value, key)
+}
+
+// Delete removes key and the value it maps to.
+func (b *BiTree[K, V]) Delete(key K) {
+	if value, ok := b.forward.Search(key); ok {
+		b.forward.Delete(key)
+		b.inverse.Delete(value)
+	}
+}
+
+// DeleteByValue removes value and the key mapped to it.
+func (b *BiTree[K, V]) DeleteByValue(value V) {
+	if key, ok := b.inverse.Search(value); ok {
+		b.inverse.Delete(value)
+		b.forward.Delete(key)
+	}
+}
+
+// Forward returns the key->value side, ordered by key.
+func (b *BiTree[K, V]) Forward() llrb.ReadOnlyTree[K, V] {
+	return b.forward.ReadOnly()
+}
+
+// Inverse returns the value->key side, ordered by value.
+func (b *BiTree[K, V]) Inverse() llrb.ReadOnlyTree[V, K] {
+	return b.inverse.ReadOnly()
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package bimap_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb"
+	"github.com/alexandremahdhaoui/llrb/bimap"
+)
+
+func TestBiTree(t *testing.T) {
+	var b bimap.BiTree[int, string]
+
+	b.Insert(1, "one")
+	b.Insert(2, "two")
+	b.Insert(3, "three")
+
+	if v, ok := b.Search(2); !ok || v != "two" {
+		t.Errorf("Search(2) = (%q, %t)", v, ok)
+	}
+
+	if k, ok := b.SearchByValue("three"); !ok || k != 3 {
+		t.Errorf("SearchByValue(three) = (%d, %t)", k, ok)
+	}
+
+	if got := b.Inverse().AppendKeysTo(nil); !slices.Equal(got, []string{"one", "three", "two"}) {
+		t.Errorf("values in order = %v", got)
+	}
+
+	// -- remapping a key drops its old value; reusing a value drops its old key.
+	b.Insert(1, "uno")
+	b.Insert(4, "two")
+
+	want := []llrb.Entry[int, string]{{Key: 1, Value: "uno"}, {Key: 3, Value: "three"}, {Key: 4, Value: "two"}}
+	if got := b.Forward().AppendEntriesTo(nil); !slices.Equal(got, want) {
+		t.Errorf("forward entries = %v, want %v", got, want)
+	}
+
+	if _, ok := b.SearchByValue("one"); ok {
+		t.Error("stale value still mapped after remapping its key")
+	}
+
+	b.Delete(3)
+	b.DeleteByValue("uno")
+
+	if got := b.Forward().AppendKeysTo(nil); !slices.Equal(got, []int{4}) {
+		t.Errorf("keys after deletions = %v, want [4]", got)
+	}
+
+	if got := b.Inverse().AppendKeysTo(nil); !slices.Equal(got, []string{"two"}) {
+		t.Errorf("values after deletions = %v, want [two]", got)
+	}
+}
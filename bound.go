@@ -0,0 +1,126 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/alexandremahdhaoui/llrb/internal"
+)
+
+// ------------------------------------------------------------------------------
+// -- BOUNDS
+//
+// A Bound is one endpoint of a key range: a key that is itself part of the
+// range (Included), a key that is not (Excluded), or no limit at all
+// (Unbounded). A pair of bounds expresses any range precisely, e.g.
+// (lo, hi] as Excluded(lo), Included(hi) or [lo, +inf) as Included(lo),
+// Unbounded[K]().
+// ------------------------------------------------------------------------------
+
+type boundKind uint8
+
+const (
+	unbounded boundKind = iota
+	included
+	excluded
+)
+
+type Bound[K cmp.Ordered] struct {
+	key  K
+	kind boundKind
+}
+
+// Included returns a bound at key, with key inside the range.
+func Included[K cmp.Ordered](key K) Bound[K] {
+	return Bound[K]{key: key, kind: included}
+}
+
+// Excluded returns a bound at key, with key outside the range.
+func Excluded[K cmp.Ordered](key K) Bound[K] {
+	return Bound[K]{key: key, kind: excluded}
+}
+
+// Unbounded returns the bound that does not limit the range. It is also the
+// zero value of Bound.
+func Unbounded[K cmp.Ordered]() Bound[K] {
+	return Bound[K]{}
+}
+
+// Key returns the key of the bound. The boolean is false for Unbounded.
+func (b Bound[K]) Key() (K, bool) {
+	return b.key, b.kind != unbounded
+}
+
+// IsIncluded reports whether the key of the bound is part of the range.
+func (b Bound[K]) IsIncluded() bool {
+	return b.kind == included
+}
+
+// admitsAbove reports whether key satisfies b used as a lower bound.
+func (b Bound[K]) admitsAbove(key K) bool {
+	switch b.kind {
+	case included:
+		return cmp.Compare(key, b.key) >= 0
+	case excluded:
+		return cmp.Compare(key, b.key) > 0
+	default:
+		return true
+	}
+}
+
+// admitsBelow reports whether key satisfies b used as an upper bound.
+func (b Bound[K]) admitsBelow(key K) bool {
+	switch b.kind {
+	case included:
+		return cmp.Compare(key, b.key) <= 0
+	case excluded:
+		return cmp.Compare(key, b.key) < 0
+	default:
+		return true
+	}
+}
+
+// Range returns the entries whose key lies between lo and hi, in ascending key
+// order. The tree must not be mutated while the sequence is being consumed.
+func (t *Tree[K, V]) Range(lo, hi Bound[K]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		internal.InOrderRange(t.root, lo.admitsAbove, hi.admitsBelow, func(n *internal.Node[K, V]) bool {
+			return yield(n.Key, n.Value)
+		})
+	}
+}
+
+// DeleteRange removes every entry whose key lies between lo and hi and returns
+// how many were removed.
+func (t *Tree[K, V]) DeleteRange(lo, hi Bound[K]) int {
+	var keys []K
+	for key := range t.Range(lo, hi) {
+		keys = append(keys, key)
+	}
+
+	for _, key := range keys {
+		t.Delete(key)
+	}
+
+	return len(keys)
+}
+
+// Within returns a view over the keys lying between lo and hi.
+func (t *Tree[K, V]) Within(lo, hi Bound[K]) *View[K, V] {
+	return &View[K, V]{tree: t, lo: lo, hi: hi}
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb"
+)
+
+func TestRange(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{1, 2, 3, 4, 5}, []string{"a", "b", "c", "d", "e"})
+	unbounded := llrb.Unbounded[int]()
+
+	for _, tt := range []struct {
+		name   string
+		lo, hi llrb.Bound[int]
+		want   []int
+	}{
+		{name: "[2, 4]", lo: llrb.Included(2), hi: llrb.Included(4), want: []int{2, 3, 4}},
+		{name: "(2, 4]", lo: llrb.Excluded(2), hi: llrb.Included(4), want: []int{3, 4}},
+		{name: "[2, 4)", lo: llrb.Included(2), hi: llrb.Excluded(4), want: []int{2, 3}},
+		{name: "(2, 4)", lo: llrb.Excluded(2), hi: llrb.Excluded(4), want: []int{3}},
+		{name: "(3, +inf)", lo: llrb.Excluded(3), hi: unbounded, want: []int{4, 5}},
+		{name: "(-inf, 2]", lo: unbounded, hi: llrb.Included(2), want: []int{1, 2}},
+		{name: "(-inf, +inf)", lo: llrb.Bound[int]{}, hi: unbounded, want: []int{1, 2, 3, 4, 5}},
+		{name: "(3, 3]", lo: llrb.Excluded(3), hi: llrb.Included(3), want: nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for k := range tree.Range(tt.lo, tt.hi) {
+				got = append(got, k)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Range keys = %v, want %v", got, tt.want)
+			}
+
+			if got := tree.Within(tt.lo, tt.hi).AppendKeysTo(nil); !slices.Equal(got, tt.want) {
+				t.Errorf("Within keys = %v, want %v", got, tt.want)
+			}
+
+			if got := tree.Within(tt.lo, tt.hi).Len(); got != len(tt.want) {
+				t.Errorf("Within Len() = %d, want %d", got, len(tt.want))
+			}
+		})
+	}
+
+	visited := 0
+	for k := range tree.Range(unbounded, unbounded) {
+		if visited++; k == 2 {
+			break
+		}
+	}
+
+	if visited != 2 {
+		t.Errorf("Range kept going after the loop broke: %d entries visited", visited)
+	}
+}
+
+func TestDeleteRange(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{1, 2, 3, 4, 5}, []string{"a", "b", "c", "d", "e"})
+
+	if n := tree.DeleteRange(llrb.Excluded(1), llrb.Included(3)); n != 2 {
+		t.Errorf("DeleteRange((1, 3]) removed %d entries, want 2", n)
+	}
+
+	if got := tree.AppendKeysTo(nil); !slices.Equal(got, []int{1, 4, 5}) || tree.Len() != 3 {
+		t.Errorf("keys = %v, Len() = %d, want [1 4 5] and 3", got, tree.Len())
+	}
+}
+
+func TestBound(t *testing.T) {
+	if k, ok := llrb.Excluded(7).Key(); !ok || k != 7 {
+		t.Errorf("Excluded(7).Key() = (%d, %t), want (7, true)", k, ok)
+	}
+
+	if _, ok := llrb.Unbounded[int]().Key(); ok {
+		t.Error("Unbounded().Key() must report false")
+	}
+
+	if !llrb.Included(1).IsIncluded() || llrb.Excluded(1).IsIncluded() {
+		t.Error("IsIncluded must only hold for Included bounds")
+	}
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package cache provides Cache, a size-bounded LRU cache whose entries can
+// also be scanned in key order.
+package cache
+
+import (
+	"cmp"
+	"container/list"
+
+	"github.com/alexandremahdhaoui/llrb"
+)
+
+// ------------------------------------------------------------------------------
+// -- CACHE
+//
+// A Cache indexes its entries twice: an llrb tree orders them by key for
+// range scans, and a list orders them by recency for eviction. Both are kept
+// in sync by every method.
+// ------------------------------------------------------------------------------
+
+type Cache[K cmp.Ordered, V any] struct {
+	capacity int
+
+	// index maps keys to their element in recency.
+	index llrb.Tree[K, *list.Element]
+	// recency holds llrb.Entry[K, V] values, most recently used first.
+	recency *list.List
+}
+
+// New returns an empty cache holding at most capacity entries. A capacity of
+// zero or less disables automatic eviction.
+func New[K cmp.Ordered, V any](capacity int) *Cache[K, V] {
+	return &Cache[K, V]{
+		capacity: capacity,
+		recency:  list.New(),
+	}
+}
+
+// Get returns the value cached under key and marks it as most recently used.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	e, ok := c.index.Search(key)
+	if !ok {
+		var zeroVal V
+		return zeroVal, false
+	}
+
+	c.recency.MoveToFront(e)
+
+	return e.Value.(llrb.Entry[K, V]).Value, true
+}
+
+// Peek is like Get but leaves the recency order untouched.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	e, ok := c.index.Search(key)
+	if !ok {
+		var zeroVal V
+		return zeroVal, false
+	}
+
+	return e.Value.(llrb.Entry[K, V]).Value, true
+}
+
+// Put caches value under key and marks it as most recently used. If the cache
+// grows beyond its capacity, the least recently used entry is evicted.
+func (c *Cache[K, V]) Put(key K, value V) {
+	entry := llrb.Entry[K, V]{Key: key, Value: value}
+
+	if e, ok := c.index.Search(key); ok {
+		e.Value = entry
+		c.recency.MoveToFront(e)
+
+		return
+	}
+
+	c.index.Insert(key, c.recency.PushFront(entry))
+
+	if c.capacity > 0 && c.recency.Len() > c.capacity {
+		c.EvictOldest()
+	}
+}
+
+// Delete removes key from the cache.
+func (c *Cache[K, V]) Delete(key K) {
+	if e, ok := c.index.Search(key); ok {
+		c.index.Delete(key)
+		c.recency.Remove(e)
+	}
+}
+
+// EvictOldest removes the least recently used entry and returns it. The
+// boolean is false if the cache is empty.
+func (c *Cache[K, V]) EvictOldest() (K, V, bool) {
+	e := c.recency.Back()
+	if e == nil {
+		var zeroKey K
+		var zeroVal V
+		return zeroKey, zeroVal, false
+	}
+
+	entry := c.recency.Remove(e).(llrb.Entry[K, V])
+	c.index.Delete(entry.Key)
+
+	return entry.Key, entry.Value, true
+}
+
+// Len returns the number of cached entries.
+func (c *Cache[K, V]) Len() int {
+	return c.recency.Len()
+}
+
+// Range returns the cached entries whose key k satisfies lo <= k < hi, in
+// ascending key order, without affecting their recency.
+func (c *Cache[K, V]) Range(lo, hi K) []llrb.Entry[K, V] {
+	var out []llrb.Entry[K, V]
+	for _, e := range c.index.SubMap(lo, hi).AppendEntriesTo(nil) {
+		out = append(out, e.Value.Value.(llrb.Entry[K, V]))
+	}
+
+	return out
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cache_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb"
+	"github.com/alexandremahdhaoui/llrb/cache"
+)
+
+func TestCache(t *testing.T) {
+	c := cache.New[int, string](3)
+
+	c.Put(3, "c")
+	c.Put(1, "a")
+	c.Put(2, "b")
+
+	// -- touching 3 makes 1 the least recently used entry.
+	if v, ok := c.Get(3); !ok || v != "c" {
+		t.Errorf("Get(3) = (%q, %t)", v, ok)
+	}
+
+	c.Put(4, "d")
+
+	if _, ok := c.Peek(1); ok {
+		t.Error("least recently used entry 1 was not evicted")
+	}
+
+	if c.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", c.Len())
+	}
+
+	want := []llrb.Entry[int, string]{{Key: 2, Value: "b"}, {Key: 3, Value: "c"}}
+	if got := c.Range(0, 4); !slices.Equal(got, want) {
+		t.Errorf("Range(0, 4) = %v, want %v", got, want)
+	}
+
+	c.Put(2, "B")
+
+	if k, v, ok := c.EvictOldest(); !ok || k != 3 || v != "c" {
+		t.Errorf("EvictOldest() = (%d, %q, %t), want (3, \"c\", true)", k, v, ok)
+	}
+
+	c.Delete(4)
+
+	if got := c.Range(0, 10); !slices.Equal(got, []llrb.Entry[int, string]{{Key: 2, Value: "B"}}) {
+		t.Errorf("Range(0, 10) = %v", got)
+	}
+
+	c.Delete(2)
+
+	if _, _, ok := c.EvictOldest(); ok {
+		t.Error("EvictOldest on an empty cache must report false")
+	}
+}
+
+func TestCacheUnbounded(t *testing.T) {
+	c := cache.New[int, int](0)
+	for i := range 100 {
+		c.Put(i, i)
+	}
+
+	if c.Len() != 100 {
+		t.Errorf("Len() = %d, want 100", c.Len())
+	}
+}
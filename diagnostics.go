@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+
+	"github.com/alexandremahdhaoui/llrb/internal"
+)
+
+// ------------------------------------------------------------------------------
+// -- DIAGNOSTICS
+// ------------------------------------------------------------------------------
+
+// Shape describes how well balanced a tree is.
+type Shape struct {
+	// Nodes is the number of entries in the tree.
+	Nodes int
+	// PathLengths is a histogram of root-to-leaf path lengths: PathLengths[d]
+	// counts the nil links reached after crossing d nodes from the root. A
+	// tree of n nodes has n+1 such paths.
+	PathLengths []int
+	// RedLinks is the number of links pointing to a red node.
+	RedLinks int
+}
+
+// Height returns the length of the longest root-to-leaf path.
+func (s Shape) Height() int {
+	return max(len(s.PathLengths)-1, 0)
+}
+
+// RedLinkRatio returns the fraction of links that are red, or 0 for trees
+// with fewer than two nodes.
+func (s Shape) RedLinkRatio() float64 {
+	if s.Nodes < 2 {
+		return 0
+	}
+
+	return float64(s.RedLinks) / float64(s.Nodes-1)
+}
+
+// Shape walks the whole tree and returns its shape diagnostics.
+func (t *Tree[K, V]) Shape() Shape {
+	var s Shape
+
+	var walk func(n *internal.Node[K, V], depth int)
+	walk = func(n *internal.Node[K, V], depth int) {
+		if n == nil {
+			for len(s.PathLengths) <= depth {
+				s.PathLengths = append(s.PathLengths, 0)
+			}
+
+			s.PathLengths[depth]++
+
+			return
+		}
+
+		s.Nodes++
+		if internal.IsRed(n) && n != t.root {
+			s.RedLinks++
+		}
+
+		walk(n.Left(), depth+1)
+		walk(n.Right(), depth+1)
+	}
+
+	walk(t.root, 0)
+
+	return s
+}
+
+// WalkDepth calls fn, in ascending key order, on the entries whose node lies at
+// most maxDepth links below the root, along with that depth (0 for the root).
+// Deeper nodes are never visited, so the cost depends on maxDepth rather than
+// on the size of the tree. The walk stops as soon as fn returns false.
+func (t *Tree[K, V]) WalkDepth(maxDepth int, fn func(depth int, key K, value V) bool) {
+	internal.InOrderDepth(t.root, maxDepth, func(n *internal.Node[K, V], depth int) bool {
+		return fn(depth, n.Key, n.Value)
+	})
+}
+
+// LevelOrder returns the entries of the tree breadth-first, each paired with
+// the depth of its node (0 for the root): level by level from the root down,
+// and in ascending key order within a level. The tree must not be mutated
+// while the sequence is being consumed.
+func (t *Tree[K, V]) LevelOrder() iter.Seq2[int, Entry[K, V]] {
+	return func(yield func(int, Entry[K, V]) bool) {
+		internal.LevelOrder(t.root, func(n *internal.Node[K, V], depth int) bool {
+			return yield(depth, Entry[K, V]{Key: n.Key, Value: n.Value})
+		})
+	}
+}
+
+// Step is one node visited while looking a key up.
+type Step[K cmp.Ordered] struct {
+	// Key is the key of the visited node.
+	Key K
+	// Order is the sign of the searched key compared to Key: -1 if the search
+	// went on to the left, 1 if it went to the right and 0 if Key matched.
+	Order int
+}
+
+func (s Step[K]) String() string {
+	switch {
+	case s.Order < 0:
+		return fmt.Sprintf("< %v: left", s.Key)
+	case s.Order > 0:
+		return fmt.Sprintf("> %v: right", s.Key)
+	default:
+		return fmt.Sprintf("= %v: found", s.Key)
+	}
+}
+
+// Explain looks key up like Search does and returns the path it took, from the
+// root down. The key was found if and only if the last step has Order 0.
+func (t *Tree[K, V]) Explain(key K) []Step[K] {
+	var steps []Step[K]
+
+	internal.SearchNodeTrace(t.root, key, func(n *internal.Node[K, V], order int) {
+		steps = append(steps, Step[K]{Key: n.Key, Order: order})
+	})
+
+	return steps
+}
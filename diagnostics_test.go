@@ -0,0 +1,165 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb_test
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb"
+)
+
+func TestShape(t *testing.T) {
+	tree := &llrb.Tree[int, int]{}
+
+	if s := tree.Shape(); s.Nodes != 0 || s.Height() != 0 || !slices.Equal(s.PathLengths, []int{1}) {
+		t.Errorf("empty tree shape = %+v", s)
+	}
+
+	// -- inserting 1..7 in order yields a perfect, all-black tree.
+	for k := 1; k <= 7; k++ {
+		tree.Insert(k, k)
+	}
+
+	s := tree.Shape()
+	if s.Nodes != 7 || s.RedLinks != 0 || s.RedLinkRatio() != 0 {
+		t.Errorf("perfect tree shape = %+v", s)
+	}
+
+	if !slices.Equal(s.PathLengths, []int{0, 0, 0, 8}) || s.Height() != 3 {
+		t.Errorf("perfect tree PathLengths = %v, Height() = %d", s.PathLengths, s.Height())
+	}
+
+	tree.Insert(8, 8)
+
+	s = tree.Shape()
+	if s.RedLinks != 1 || math.Abs(s.RedLinkRatio()-1.0/7) > 1e-9 {
+		t.Errorf("RedLinks = %d, RedLinkRatio() = %g, want 1 and 1/7", s.RedLinks, s.RedLinkRatio())
+	}
+
+	if !slices.Equal(s.PathLengths, []int{0, 0, 0, 7, 2}) {
+		t.Errorf("PathLengths = %v, want [0 0 0 7 2]", s.PathLengths)
+	}
+}
+
+func TestWalkDepth(t *testing.T) {
+	tree := &llrb.Tree[int, int]{}
+
+	// -- 1..7 inserted in order: 4 at the root, then 2 and 6, then the odd keys.
+	for k := 1; k <= 7; k++ {
+		tree.Insert(k, -k)
+	}
+
+	for _, tt := range []struct {
+		maxDepth   int
+		wantKeys   []int
+		wantDepths []int
+	}{
+		{maxDepth: -1, wantKeys: nil, wantDepths: nil},
+		{maxDepth: 0, wantKeys: []int{4}, wantDepths: []int{0}},
+		{maxDepth: 1, wantKeys: []int{2, 4, 6}, wantDepths: []int{1, 0, 1}},
+		{maxDepth: 9, wantKeys: []int{1, 2, 3, 4, 5, 6, 7}, wantDepths: []int{2, 1, 2, 0, 2, 1, 2}},
+	} {
+		var keys, depths []int
+
+		tree.WalkDepth(tt.maxDepth, func(depth, key, value int) bool {
+			if value != -key {
+				t.Errorf("WalkDepth(%d) passed value %d for key %d", tt.maxDepth, value, key)
+			}
+
+			keys, depths = append(keys, key), append(depths, depth)
+
+			return true
+		})
+
+		if !slices.Equal(keys, tt.wantKeys) || !slices.Equal(depths, tt.wantDepths) {
+			t.Errorf("WalkDepth(%d) visited keys %v at depths %v, want %v at %v",
+				tt.maxDepth, keys, depths, tt.wantKeys, tt.wantDepths)
+		}
+	}
+
+	visited := 0
+	tree.WalkDepth(9, func(int, int, int) bool {
+		visited++
+		return visited < 3
+	})
+
+	if visited != 3 {
+		t.Errorf("WalkDepth visited %d entries after fn returned false on the 3rd", visited)
+	}
+}
+
+func TestLevelOrder(t *testing.T) {
+	tree := &llrb.Tree[int, int]{}
+
+	for range tree.LevelOrder() {
+		t.Fatal("LevelOrder on an empty tree must yield nothing")
+	}
+
+	// -- 1..8 inserted in order: 7 and 8 form a 3-node under 6, with 7 as the
+	// red left child of 8.
+	for k := 1; k <= 8; k++ {
+		tree.Insert(k, -k)
+	}
+
+	var keys, depths []int
+	for depth, e := range tree.LevelOrder() {
+		if e.Value != -e.Key {
+			t.Errorf("LevelOrder yielded %+v", e)
+		}
+
+		keys, depths = append(keys, e.Key), append(depths, depth)
+	}
+
+	if want := []int{4, 2, 6, 1, 3, 5, 8, 7}; !slices.Equal(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+
+	if want := []int{0, 1, 1, 2, 2, 2, 2, 3}; !slices.Equal(depths, want) {
+		t.Errorf("depths = %v, want %v", depths, want)
+	}
+
+	for depth := range tree.LevelOrder() {
+		if depth > 0 {
+			break
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	tree := &llrb.Tree[int, int]{}
+
+	if steps := tree.Explain(1); len(steps) != 0 {
+		t.Errorf("Explain on an empty tree = %v, want no steps", steps)
+	}
+
+	// -- 1..7 inserted in order: 4 at the root, then 2 and 6.
+	for k := 1; k <= 7; k++ {
+		tree.Insert(k, k)
+	}
+
+	want := []llrb.Step[int]{{Key: 4, Order: 1}, {Key: 6, Order: -1}, {Key: 5}}
+	if got := tree.Explain(5); !slices.Equal(got, want) {
+		t.Errorf("Explain(5) = %v, want %v", got, want)
+	}
+
+	got := fmt.Sprint(tree.Explain(8))
+	if want := "[> 4: right > 6: right > 7: right]"; got != want {
+		t.Errorf("Explain(8) = %s, want %s", got, want)
+	}
+}
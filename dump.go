@@ -0,0 +1,79 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb
+
+import (
+	"cmp"
+
+	"github.com/alexandremahdhaoui/llrb/internal"
+)
+
+// ------------------------------------------------------------------------------
+// -- DUMP
+//
+// Dumps describe the exact structure of a tree (keys, colors and shape) in a
+// human-readable form, so tests can assert against golden files and rebuild
+// the precise shape that triggered a balancing bug:
+//
+//	B 4
+//	  R 2
+//	    B 1
+//	    B 3
+//	  B 5
+//
+// Each line holds a node's color (B or R) and key, indented two spaces per
+// level, followed by its left and right children unless both are nil; "-"
+// stands for a nil child. Values are not part of a dump.
+// ------------------------------------------------------------------------------
+
+// ErrMalformedDump is wrapped by the errors ParseDump returns for invalid
+// input.
+var ErrMalformedDump = internal.ErrMalformedDump
+
+// Dump returns the structural dump of the tree. Keys are formatted with %v.
+func (t *Tree[K, V]) Dump() string {
+	return internal.Dump(t.root)
+}
+
+// ParseDump rebuilds the tree described by dump, decoding keys with parseKey.
+// Values are left to their zero value. A dump describing a tree that breaks
+// the LLRB invariants is rejected with the *ViolationError Validate reports;
+// use ParseDumpUnchecked to reproduce such shapes.
+func ParseDump[K cmp.Ordered, V any](dump string, parseKey func(string) (K, error)) (*Tree[K, V], error) {
+	t, err := ParseDumpUnchecked[K, V](dump, parseKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// ParseDumpUnchecked is like ParseDump but does not enforce the LLRB
+// invariants, so that corrupted shapes can be reproduced and inspected with
+// Validate, Dump and the other read-only methods. Mutating a tree that does
+// not pass Validate may panic.
+func ParseDumpUnchecked[K cmp.Ordered, V any](dump string, parseKey func(string) (K, error)) (*Tree[K, V], error) {
+	root, err := internal.Parse[K, V](dump, parseKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tree[K, V]{root: root, size: internal.Size(root)}, nil
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb"
+)
+
+func TestDump(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{1, 2, 3, 4, 5}, make([]string, 5))
+
+	golden := `B 4
+  R 2
+    B 1
+    B 3
+  B 5
+`
+	if got := tree.Dump(); got != golden {
+		t.Fatalf("Dump() =\n%s\nwant\n%s", got, golden)
+	}
+
+	parsed, err := llrb.ParseDump[int, string](golden, strconv.Atoi)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := parsed.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if parsed.Len() != 5 {
+		t.Errorf("Len() = %d, want 5", parsed.Len())
+	}
+
+	parsed.Insert(6, "f")
+	tree.Insert(6, "f")
+
+	if parsed.Dump() != tree.Dump() {
+		t.Errorf("parsed tree evolves differently:\n%s\nwant\n%s", parsed.Dump(), tree.Dump())
+	}
+
+	if _, err := llrb.ParseDump[int, string]("B x\n", strconv.Atoi); !errors.Is(err, llrb.ErrMalformedDump) {
+		t.Errorf("ParseDump() = %v, want ErrMalformedDump", err)
+	}
+}
+
+func TestParseDumpCorrupted(t *testing.T) {
+	// -- 2 has a nil left child but a non-nil right one: not left-leaning.
+	const corrupted = "B 2\n  -\n  B 3\n"
+
+	_, err := llrb.ParseDump[int, string](corrupted, strconv.Atoi)
+
+	var violation *llrb.ViolationError[int]
+	if !errors.Is(err, llrb.ErrCorrupted) || !errors.As(err, &violation) {
+		t.Fatalf("ParseDump() = %v, want a *ViolationError", err)
+	}
+
+	tree, err := llrb.ParseDumpUnchecked[int, string](corrupted, strconv.Atoi)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tree.Len() != 2 || tree.Dump() != corrupted || !errors.Is(tree.Validate(), llrb.ErrCorrupted) {
+		t.Errorf("ParseDumpUnchecked() = %d entries, Validate() = %v, dump:\n%s", tree.Len(), tree.Validate(), tree.Dump())
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/alexandremahdhaoui/llrb/internal"
+)
+
+// ------------------------------------------------------------------------------
+// -- ENTRY
+// ------------------------------------------------------------------------------
+
+// Entry is a key/value pair stored in a Tree.
+type Entry[K cmp.Ordered, V any] struct {
+	Key   K
+	Value V
+}
+
+// ------------------------------------------------------------------------------
+// -- SLICE INTEROP
+// ------------------------------------------------------------------------------
+
+// FromSortedKVs builds a tree mapping keys[i] to values[i]. Keys are expected
+// in ascending order, which keeps every insertion on the right spine; unsorted
+// input still yields a correct tree, and duplicate keys keep their last value.
+// It panics if keys and values have different lengths.
+func FromSortedKVs[K cmp.Ordered, V any](keys []K, values []V) *Tree[K, V] {
+	if len(keys) != len(values) {
+		panic("llrb: FromSortedKVs called with keys and values of different lengths")
+	}
+
+	t := &Tree[K, V]{}
+	for i, key := range keys {
+		t.Insert(key, values[i])
+	}
+
+	return t
+}
+
+// AppendKeysTo appends the keys of the tree to dst in ascending order and
+// returns the extended slice.
+func (t *Tree[K, V]) AppendKeysTo(dst []K) []K {
+	dst = slices.Grow(dst, t.size)
+
+	internal.InOrder(t.root, func(n *internal.Node[K, V]) bool {
+		dst = append(dst, n.Key)
+		return true
+	})
+
+	return dst
+}
+
+// AppendEntriesTo appends the entries of the tree to dst in ascending key
+// order and returns the extended slice.
+func (t *Tree[K, V]) AppendEntriesTo(dst []Entry[K, V]) []Entry[K, V] {
+	dst = slices.Grow(dst, t.size)
+
+	internal.InOrder(t.root, func(n *internal.Node[K, V]) bool {
+		dst = append(dst, Entry[K, V]{Key: n.Key, Value: n.Value})
+		return true
+	})
+
+	return dst
+}
+
+// MinN returns the k entries with the smallest keys, in ascending key order.
+// It returns every entry if the tree holds fewer than k, and visits no more
+// nodes than needed.
+func (t *Tree[K, V]) MinN(k int) []Entry[K, V] {
+	dst := make([]Entry[K, V], 0, min(max(k, 0), t.size))
+
+	internal.InOrder(t.root, func(n *internal.Node[K, V]) bool {
+		if len(dst) >= k {
+			return false
+		}
+
+		dst = append(dst, Entry[K, V]{Key: n.Key, Value: n.Value})
+
+		return true
+	})
+
+	return dst
+}
+
+// MaxN returns the k entries with the largest keys, in descending key order.
+// It returns every entry if the tree holds fewer than k, and visits no more
+// nodes than needed.
+func (t *Tree[K, V]) MaxN(k int) []Entry[K, V] {
+	dst := make([]Entry[K, V], 0, min(max(k, 0), t.size))
+	always := func(K) bool { return true }
+
+	internal.ReverseInOrderRange(t.root, always, always, func(n *internal.Node[K, V]) bool {
+		if len(dst) >= k {
+			return false
+		}
+
+		dst = append(dst, Entry[K, V]{Key: n.Key, Value: n.Value})
+
+		return true
+	})
+
+	return dst
+}
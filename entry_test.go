@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb"
+)
+
+func TestFromSortedKVs(t *testing.T) {
+	keys := []int{1, 2, 3, 5, 8, 13}
+	values := []string{"a", "b", "c", "e", "h", "m"}
+
+	tree := llrb.FromSortedKVs(keys, values)
+
+	for i, k := range keys {
+		if v, ok := tree.Search(k); !ok || v != values[i] {
+			t.Errorf("Search(%d) = (%q, %t), want (%q, true)", k, v, ok, values[i])
+		}
+	}
+
+	if got := tree.AppendKeysTo(nil); !slices.Equal(got, keys) {
+		t.Errorf("AppendKeysTo(nil) = %v, want %v", got, keys)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("FromSortedKVs must panic on mismatched lengths")
+		}
+	}()
+
+	llrb.FromSortedKVs([]int{1, 2}, []string{"a"})
+}
+
+func TestAppendKeysTo(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{3, 1, 2}, []int{30, 10, 20})
+
+	dst := make([]int, 1, 8)
+	dst[0] = -1
+
+	got := tree.AppendKeysTo(dst)
+	if !slices.Equal(got, []int{-1, 1, 2, 3}) {
+		t.Errorf("AppendKeysTo = %v, want [-1 1 2 3]", got)
+	}
+
+	if &got[0] != &dst[0] {
+		t.Error("AppendKeysTo reallocated although dst had enough capacity")
+	}
+
+	if got := (&llrb.Tree[int, int]{}).AppendKeysTo(nil); got != nil {
+		t.Errorf("AppendKeysTo on an empty tree = %v, want nil", got)
+	}
+}
+
+func TestAppendEntriesTo(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{3, 1, 2}, []int{30, 10, 20})
+
+	want := []llrb.Entry[int, int]{{Key: 1, Value: 10}, {Key: 2, Value: 20}, {Key: 3, Value: 30}}
+	if got := tree.AppendEntriesTo(nil); !slices.Equal(got, want) {
+		t.Errorf("AppendEntriesTo(nil) = %v, want %v", got, want)
+	}
+}
+
+func TestMinNMaxN(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{1, 2, 3, 4, 5}, []string{"a", "b", "c", "d", "e"})
+
+	if got, want := tree.MinN(2), []llrb.Entry[int, string]{{1, "a"}, {2, "b"}}; !slices.Equal(got, want) {
+		t.Errorf("MinN(2) = %v, want %v", got, want)
+	}
+
+	if got, want := tree.MaxN(2), []llrb.Entry[int, string]{{5, "e"}, {4, "d"}}; !slices.Equal(got, want) {
+		t.Errorf("MaxN(2) = %v, want %v", got, want)
+	}
+
+	if got := tree.MinN(10); len(got) != 5 {
+		t.Errorf("MinN(10) returned %d entries, want 5", len(got))
+	}
+
+	if got := tree.MaxN(0); len(got) != 0 {
+		t.Errorf("MaxN(0) = %v, want no entries", got)
+	}
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/alexandremahdhaoui/llrb/internal"
+)
+
+// ------------------------------------------------------------------------------
+// -- FROZEN
+//
+// A Frozen is an immutable snapshot of a Tree laid out as two parallel sorted
+// slices. Lookups are binary searches and scans walk contiguous memory, which
+// suits read-mostly phases of a workload.
+// ------------------------------------------------------------------------------
+
+type Frozen[K cmp.Ordered, V any] struct {
+	keys   []K
+	values []V
+
+	// find locates key in keys. A nil find defaults to binary search.
+	find func(keys []K, key K) (int, bool)
+}
+
+// Freeze returns an immutable copy of the tree's content. Later mutations of
+// the tree are not reflected in the returned Frozen.
+func (t *Tree[K, V]) Freeze() *Frozen[K, V] {
+	f := &Frozen[K, V]{
+		keys:   make([]K, 0, t.size),
+		values: make([]V, 0, t.size),
+	}
+
+	internal.InOrder(t.root, func(n *internal.Node[K, V]) bool {
+		f.keys = append(f.keys, n.Key)
+		f.values = append(f.values, n.Value)
+		return true
+	})
+
+	return f
+}
+
+// Thaw builds a new mutable Tree holding the frozen entries.
+func (f *Frozen[K, V]) Thaw() *Tree[K, V] {
+	t := &Tree[K, V]{}
+	for i, key := range f.keys {
+		t.Insert(key, f.values[i])
+	}
+
+	return t
+}
+
+func (f *Frozen[K, V]) Search(key K) (V, bool) {
+	find := f.find
+	if find == nil {
+		find = slices.BinarySearch[[]K]
+	}
+
+	if i, ok := find(f.keys, key); ok {
+		return f.values[i], true
+	}
+
+	var zeroVal V
+	return zeroVal, false
+}
+
+func (f *Frozen[K, V]) Len() int {
+	return len(f.keys)
+}
+
+// Keys returns the frozen keys in ascending order. The returned slice must not
+// be modified.
+func (f *Frozen[K, V]) Keys() []K {
+	return f.keys
+}
+
+// Values returns the frozen values ordered by their keys. The returned slice
+// must not be modified.
+func (f *Frozen[K, V]) Values() []V {
+	return f.values
+}
+
+// ------------------------------------------------------------------------------
+// -- INTERPOLATION SEARCH
+// ------------------------------------------------------------------------------
+
+// Number is the set of key types interpolation search can estimate positions
+// for.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// UseInterpolationSearch switches the lookups of f to interpolation search and
+// returns f. On uniformly distributed keys a lookup takes O(log log n) probes
+// instead of O(log n); on heavily skewed keys it may degrade to O(n).
+func UseInterpolationSearch[K Number, V any](f *Frozen[K, V]) *Frozen[K, V] {
+	f.find = interpolationSearch[K]
+	return f
+}
+
+func interpolationSearch[K Number](keys []K, key K) (int, bool) {
+	lo, hi := 0, len(keys)-1
+
+	// -- a NaN key sorts first and cannot be interpolated against: settle it
+	// before estimating positions over the remaining keys.
+	if lo <= hi && isNaN(keys[lo]) {
+		if isNaN(key) {
+			return lo, true
+		}
+
+		lo++
+	}
+
+	for lo <= hi && cmp.Compare(key, keys[lo]) >= 0 && cmp.Compare(key, keys[hi]) <= 0 {
+		if cmp.Compare(keys[lo], keys[hi]) == 0 {
+			return lo, cmp.Compare(keys[lo], key) == 0
+		}
+
+		// -- estimate in float64 so that subtracting keys cannot overflow.
+		ratio := (float64(key) - float64(keys[lo])) / (float64(keys[hi]) - float64(keys[lo]))
+		pos := lo + int(ratio*float64(hi-lo))
+		pos = min(max(pos, lo), hi)
+
+		switch c := cmp.Compare(keys[pos], key); {
+		case c == 0:
+			return pos, true
+		case c < 0:
+			lo = pos + 1
+		default:
+			hi = pos - 1
+		}
+	}
+
+	return lo, false
+}
+
+// isNaN reports whether x is a floating-point NaN.
+func isNaN[K Number](x K) bool {
+	return x != x
+}
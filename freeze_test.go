@@ -0,0 +1,127 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb"
+)
+
+func TestFreeze(t *testing.T) {
+	tree := &llrb.Tree[int, string]{}
+	for _, k := range []int{4, 2, 6, 1, 3, 5, 7} {
+		tree.Insert(k, string(rune('a'+k)))
+	}
+
+	f := tree.Freeze()
+	tree.Insert(8, "i")
+
+	if f.Len() != 7 {
+		t.Fatalf("Len() = %d, want 7", f.Len())
+	}
+
+	if !slices.Equal(f.Keys(), []int{1, 2, 3, 4, 5, 6, 7}) {
+		t.Errorf("Keys() = %v, want sorted keys", f.Keys())
+	}
+
+	for k := 1; k <= 7; k++ {
+		if v, ok := f.Search(k); !ok || v != string(rune('a'+k)) {
+			t.Errorf("Search(%d) = (%q, %t)", k, v, ok)
+		}
+	}
+
+	if _, ok := f.Search(8); ok {
+		t.Error("frozen snapshot must not see later insertions")
+	}
+
+	thawed := f.Thaw()
+	thawed.Delete(1)
+
+	if v, ok := thawed.Search(7); !ok || v != "h" {
+		t.Errorf("thawed Search(7) = (%q, %t)", v, ok)
+	}
+
+	if _, ok := f.Search(1); !ok {
+		t.Error("mutating a thawed tree must not affect the snapshot")
+	}
+}
+
+func TestUseInterpolationSearch(t *testing.T) {
+	tree := &llrb.Tree[int64, int64]{}
+	for k := int64(0); k < 1000; k += 3 {
+		tree.Insert(k, -k)
+	}
+
+	tree.Insert(math.MinInt64, 1)
+	tree.Insert(math.MaxInt64, 2)
+
+	f := llrb.UseInterpolationSearch(tree.Freeze())
+
+	for k := int64(-5); k < 1005; k++ {
+		v, ok := f.Search(k)
+		want := k >= 0 && k < 1000 && k%3 == 0
+		if ok != want || (ok && v != -k) {
+			t.Fatalf("Search(%d) = (%d, %t), want found=%t", k, v, ok, want)
+		}
+	}
+
+	for k, want := range map[int64]int64{math.MinInt64: 1, math.MaxInt64: 2} {
+		if v, ok := f.Search(k); !ok || v != want {
+			t.Errorf("Search(%d) = (%d, %t), want (%d, true)", k, v, ok, want)
+		}
+	}
+}
+
+func TestUseInterpolationSearchFloat(t *testing.T) {
+	tree := &llrb.Tree[float64, bool]{}
+	for _, k := range []float64{-2.5, 0, 0.1, 1, 7.75, 1e9} {
+		tree.Insert(k, true)
+	}
+
+	f := llrb.UseInterpolationSearch(tree.Freeze())
+
+	for _, k := range []float64{-2.5, 0, 0.1, 1, 7.75, 1e9} {
+		if _, ok := f.Search(k); !ok {
+			t.Errorf("Search(%g) missed a stored key", k)
+		}
+	}
+
+	for _, k := range []float64{-3, 0.05, 2, 1e10} {
+		if _, ok := f.Search(k); ok {
+			t.Errorf("Search(%g) found a missing key", k)
+		}
+	}
+
+	tree.Insert(math.NaN(), true)
+	f = llrb.UseInterpolationSearch(tree.Freeze())
+
+	for _, k := range []float64{math.NaN(), -2.5, 0, 0.1, 1, 7.75, 1e9} {
+		if _, ok := f.Search(k); !ok {
+			t.Errorf("Search(%g) missed a stored key next to a NaN key", k)
+		}
+	}
+
+	if _, ok := llrb.UseInterpolationSearch(llrb.FromSortedKVs([]float64{1}, []bool{true}).Freeze()).Search(math.NaN()); ok {
+		t.Error("Search(NaN) found a missing NaN key")
+	}
+
+	if _, ok := llrb.UseInterpolationSearch(&llrb.Frozen[int, int]{}).Search(1); ok {
+		t.Error("Search on an empty snapshot must not find anything")
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb
+
+import (
+	"cmp"
+
+	"github.com/alexandremahdhaoui/llrb/internal"
+)
+
+// ------------------------------------------------------------------------------
+// -- HANDLES
+//
+// A Handle points straight at the node holding an entry. Rebalancing only
+// relinks nodes and deletion splices a successor node in place of the removed
+// one, so an entry stays in the same node for as long as it is in the tree:
+// a handle is valid from the moment it is obtained until its entry is removed,
+// or until the whole content of the tree is replaced (Rebuild, UnmarshalYAML).
+// ------------------------------------------------------------------------------
+
+type Handle[K cmp.Ordered, V any] struct {
+	tree *Tree[K, V]
+	node *internal.Node[K, V]
+	// generation is the generation of tree when the handle was obtained.
+	generation uint64
+}
+
+// InsertHandle is like Insert and returns a handle to the entry.
+func (t *Tree[K, V]) InsertHandle(key K, value V) Handle[K, V] {
+	t.Insert(key, value)
+
+	return Handle[K, V]{tree: t, node: t.lookup(key), generation: t.generation}
+}
+
+// SearchHandle returns a handle to the entry stored under key. The boolean is
+// false if key is missing.
+func (t *Tree[K, V]) SearchHandle(key K) (Handle[K, V], bool) {
+	n := t.lookup(key)
+	if n == nil {
+		return Handle[K, V]{}, false
+	}
+
+	return Handle[K, V]{tree: t, node: n, generation: t.generation}, true
+}
+
+// DeleteHandle removes the entry h points to. It returns false and does
+// nothing if h is not valid or belongs to another tree.
+func (t *Tree[K, V]) DeleteHandle(h Handle[K, V]) bool {
+	if h.tree != t || !h.Valid() {
+		return false
+	}
+
+	t.Delete(h.node.Key)
+
+	return true
+}
+
+// Valid reports whether the entry h points to is still in its tree. The zero
+// Handle is not valid.
+func (h Handle[K, V]) Valid() bool {
+	return h.node != nil && !internal.Detached(h.node) && h.generation == h.tree.generation
+}
+
+// Key returns the key of the entry in O(1). It remains readable after the
+// entry was removed.
+func (h Handle[K, V]) Key() K {
+	return h.node.Key
+}
+
+// Value returns the value of the entry in O(1). It remains readable after the
+// entry was removed.
+func (h Handle[K, V]) Value() V {
+	return h.node.Value
+}
+
+// SetValue replaces the value of the entry in O(1). It returns false and does
+// nothing if h is not valid.
+func (h Handle[K, V]) SetValue(value V) bool {
+	if !h.Valid() {
+		return false
+	}
+
+	h.node.Value = value
+
+	return true
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb_test
+
+import (
+	"context"
+	"maps"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb"
+)
+
+func TestHandle(t *testing.T) {
+	tree := &llrb.Tree[int, int]{}
+
+	handles := make([]llrb.Handle[int, int], 200)
+	for i, k := range rand.New(rand.NewPCG(1, 2)).Perm(200) {
+		handles[k] = tree.InsertHandle(k, i)
+	}
+
+	// -- delete the odd keys through their handles: the even handles must
+	// survive every rebalancing this triggers.
+	for k := 1; k < 200; k += 2 {
+		if !tree.DeleteHandle(handles[k]) {
+			t.Fatalf("DeleteHandle(%d) reported an invalid handle", k)
+		}
+	}
+
+	for k, h := range handles {
+		if valid := h.Valid(); valid != (k%2 == 0) {
+			t.Errorf("handle %d: Valid() = %t", k, valid)
+		}
+
+		if h.Key() != k {
+			t.Errorf("handle %d points to key %d", k, h.Key())
+		}
+	}
+
+	h, ok := tree.SearchHandle(42)
+	if !ok || h != handles[42] {
+		t.Fatal("SearchHandle(42) must return the handle obtained on insertion")
+	}
+
+	if !h.SetValue(-1) {
+		t.Error("SetValue on a valid handle must succeed")
+	}
+
+	if v, _ := tree.Search(42); v != -1 || h.Value() != -1 {
+		t.Errorf("Search(42) = %d, Value() = %d after SetValue(-1)", v, h.Value())
+	}
+
+	if handles[1].SetValue(0) || tree.DeleteHandle(handles[1]) {
+		t.Error("a handle to a removed entry must be rejected")
+	}
+
+	if other := (&llrb.Tree[int, int]{}); other.DeleteHandle(h) {
+		t.Error("DeleteHandle must reject handles of another tree")
+	}
+
+	if _, ok := tree.SearchHandle(1); ok {
+		t.Error("SearchHandle(1) must report a missing key")
+	}
+
+	if (llrb.Handle[int, int]{}).Valid() {
+		t.Error("the zero Handle must not be valid")
+	}
+
+	if tree.Len() != 100 {
+		t.Errorf("Len() = %d, want 100", tree.Len())
+	}
+}
+
+func TestHandleAfterReplace(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		replace func(tree *llrb.Tree[int, string])
+	}{
+		{name: "Rebuild", replace: func(tree *llrb.Tree[int, string]) {
+			if err := tree.Rebuild(context.Background(), maps.All(map[int]string{1: "new", 2: "two"}), nil); err != nil {
+				t.Fatal(err)
+			}
+		}},
+		{name: "UnmarshalYAML", replace: func(tree *llrb.Tree[int, string]) {
+			if err := tree.UnmarshalYAML(func(v any) error {
+				*v.(*[]llrb.Entry[int, string]) = []llrb.Entry[int, string]{{Key: 1, Value: "new"}}
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := &llrb.Tree[int, string]{}
+			h := tree.InsertHandle(1, "old")
+
+			tt.replace(tree)
+
+			if h.Valid() {
+				t.Error("a handle must not be valid once the content of its tree is replaced")
+			}
+
+			if h.SetValue("stale") {
+				t.Error("SetValue on a handle to replaced content must be rejected")
+			}
+
+			if tree.DeleteHandle(h) {
+				t.Error("DeleteHandle on a handle to replaced content must be rejected")
+			}
+
+			if v, ok := tree.Search(1); !ok || v == "stale" {
+				t.Errorf("Search(1) = (%q, %t), want the entry of the new content", v, ok)
+			}
+		})
+	}
+}
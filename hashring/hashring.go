@@ -0,0 +1,144 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package hashring provides a consistent-hash ring backed by an llrb tree.
+package hashring
+
+import (
+	"hash/fnv"
+	"math"
+	"strconv"
+
+	"github.com/alexandremahdhaoui/llrb"
+)
+
+// ------------------------------------------------------------------------------
+// -- RING
+//
+// A Ring places every node at several pseudo-random positions (virtual nodes)
+// on a 64-bit hash circle. A key belongs to the first node found at or after
+// its own hash, wrapping around past the largest position. The tree orders
+// positions so that this lookup is a single O(log n) ceiling search.
+// ------------------------------------------------------------------------------
+
+type Ring struct {
+	replicas int
+
+	// positions maps each occupied position on the circle to its node.
+	positions llrb.Tree[uint64, string]
+	// owned lists the positions each node occupies.
+	owned map[string][]uint64
+}
+
+// New returns an empty ring placing each node at the given number of virtual
+// positions. More replicas spread the keys more evenly across nodes.
+func New(replicas int) *Ring {
+	return &Ring{
+		replicas: max(replicas, 1),
+		owned:    make(map[string][]uint64),
+	}
+}
+
+// AddNode places node on the ring. Adding a node twice is a no-op. A position
+// already occupied by another node is kept by that node.
+func (r *Ring) AddNode(node string) {
+	if _, ok := r.owned[node]; ok {
+		return
+	}
+
+	positions := make([]uint64, 0, r.replicas)
+
+	for i := range r.replicas {
+		pos := hash(node + "#" + strconv.Itoa(i))
+		if r.positions.Contains(pos) {
+			continue
+		}
+
+		r.positions.Insert(pos, node)
+		positions = append(positions, pos)
+	}
+
+	r.owned[node] = positions
+}
+
+// RemoveNode removes node and all its virtual positions from the ring.
+func (r *Ring) RemoveNode(node string) {
+	for _, pos := range r.owned[node] {
+		r.positions.Delete(pos)
+	}
+
+	delete(r.owned, node)
+}
+
+// Nodes returns the number of nodes on the ring.
+func (r *Ring) Nodes() int {
+	return len(r.owned)
+}
+
+// Locate returns the node responsible for key. The boolean is false if the
+// ring is empty.
+func (r *Ring) Locate(key string) (string, bool) {
+	if _, node, ok := r.positions.Ceiling(hash(key)); ok {
+		return node, true
+	}
+
+	// -- wrap around to the smallest position.
+	_, node, ok := r.positions.Min()
+
+	return node, ok
+}
+
+// Shares returns the fraction of the hash circle each node is responsible
+// for. The shares of all nodes sum to 1 on a non-empty ring; the further
+// they are from 1/Nodes(), the more unbalanced the ring.
+func (r *Ring) Shares() map[string]float64 {
+	shares := make(map[string]float64, len(r.owned))
+
+	entries := r.positions.AppendEntriesTo(nil)
+	if len(entries) == 0 {
+		return shares
+	}
+
+	// -- each position owns the arc running from its predecessor, exclusive,
+	// to itself; the first position also owns the arc wrapping past the end.
+	prev := entries[len(entries)-1].Key
+	for _, e := range entries {
+		arc := e.Key - prev // wraps around modulo 2^64 for the first position.
+		if len(entries) == 1 {
+			arc = math.MaxUint64
+		}
+
+		shares[e.Value] += float64(arc) / math.MaxUint64
+		prev = e.Key
+	}
+
+	return shares
+}
+
+// hash returns a stable 64-bit hash of s, identical across processes so that
+// rings built separately agree on key placement.
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+
+	// -- FNV barely changes its high bits when strings differ only in their
+	// last bytes, as virtual node names do; the splitmix64 finalizer spreads
+	// them over the whole circle.
+	x := h.Sum64()
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+
+	return x ^ (x >> 31)
+}
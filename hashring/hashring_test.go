@@ -0,0 +1,107 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package hashring_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb/hashring"
+)
+
+func TestRing(t *testing.T) {
+	r := hashring.New(64)
+
+	if _, ok := r.Locate("key"); ok {
+		t.Error("Locate on an empty ring must report false")
+	}
+
+	for _, node := range []string{"a", "b", "c"} {
+		r.AddNode(node)
+	}
+
+	r.AddNode("a")
+
+	if r.Nodes() != 3 {
+		t.Errorf("Nodes() = %d, want 3", r.Nodes())
+	}
+
+	before := make(map[string]string)
+	counts := make(map[string]int)
+
+	for i := range 3000 {
+		key := fmt.Sprintf("key-%d", i)
+
+		node, ok := r.Locate(key)
+		if !ok {
+			t.Fatalf("Locate(%q) found no node", key)
+		}
+
+		before[key] = node
+		counts[node]++
+	}
+
+	for node, n := range counts {
+		if n < 500 {
+			t.Errorf("node %s got %d of 3000 keys, the ring is badly unbalanced", node, n)
+		}
+	}
+
+	r.RemoveNode("b")
+
+	for key, node := range before {
+		after, _ := r.Locate(key)
+
+		if after == "b" {
+			t.Fatalf("key %q still maps to removed node b", key)
+		}
+
+		// -- only the keys of the removed node may move.
+		if node != "b" && after != node {
+			t.Fatalf("key %q moved from %s to %s although its node stayed", key, node, after)
+		}
+	}
+}
+
+func TestRingShares(t *testing.T) {
+	r := hashring.New(128)
+
+	if shares := r.Shares(); len(shares) != 0 {
+		t.Errorf("Shares() on an empty ring = %v", shares)
+	}
+
+	r.AddNode("solo")
+
+	if shares := r.Shares(); math.Abs(shares["solo"]-1) > 1e-9 {
+		t.Errorf("a single node must own the whole ring, got %v", shares)
+	}
+
+	r.AddNode("duo")
+
+	sum := 0.0
+	for node, share := range r.Shares() {
+		sum += share
+
+		if share < 0.3 || share > 0.7 {
+			t.Errorf("node %s owns %.2f of the ring, want about 0.5", node, share)
+		}
+	}
+
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("shares sum to %g, want 1", sum)
+	}
+}
@@ -15,7 +15,13 @@
  */
 package internal
 
-import "cmp"
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
 
 // ------------------------------------------------------------------------------
 // -- NODE
@@ -36,6 +42,8 @@ type Node[K cmp.Ordered, V any] struct {
 	parent   *Node[K, V]
 	children [2]*Node[K, V]
 	isBlack  bool
+	// size is the number of nodes in the subtree rooted at this node.
+	size int
 }
 
 func (n *Node[K, V]) Left() *Node[K, V] {
@@ -53,28 +61,210 @@ func NewNode[K cmp.Ordered, V any](key K, value V) *Node[K, V] {
 		parent:   nil,
 		children: [2]*Node[K, V]{},
 		isBlack:  false,
+		size:     1,
 	}
 }
 
+// Size returns the number of nodes in the subtree rooted at n.
+func Size[K cmp.Ordered, V any](n *Node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.size
+}
+
+// updateSize recomputes the size of n from the sizes of its children.
+func updateSize[K cmp.Ordered, V any](n *Node[K, V]) {
+	n.size = 1 + Size(n.Left()) + Size(n.Right())
+}
+
 // ------------------------------------------------------------------------------
 // -- SEARCH
 // ------------------------------------------------------------------------------
 
 func Search[K cmp.Ordered, V any](root *Node[K, V], key K) (V, bool) {
+	if n := SearchNode(root, key); n != nil {
+		return n.Value, true
+	}
+
+	var zeroVal V
+	return zeroVal, false
+}
+
+// SearchNode returns the node holding key, or nil if the subtree does not
+// contain it.
+func SearchNode[K cmp.Ordered, V any](root *Node[K, V], key K) *Node[K, V] {
 	for n := root; n != nil; {
-		if key == n.Key {
-			return n.Value, true
+		switch c := cmp.Compare(key, n.Key); {
+		case c < 0:
+			n = n.children[Left]
+		case c > 0:
+			n = n.children[Right]
+		default:
+			return n
 		}
+	}
+
+	return nil
+}
 
-		if key < n.Key {
+// SearchNodeTrace is SearchNode calling visit for every node it crosses, with
+// the comparison of key against the node's key (negative when descending
+// left, positive when descending right, 0 when found). It must mirror
+// SearchNode.
+func SearchNodeTrace[K cmp.Ordered, V any](
+	root *Node[K, V],
+	key K,
+	visit func(n *Node[K, V], order int),
+) *Node[K, V] {
+	for n := root; n != nil; {
+		c := cmp.Compare(key, n.Key)
+		visit(n, c)
+
+		switch {
+		case c < 0:
+			n = n.children[Left]
+		case c > 0:
 			n = n.children[Right]
+		default:
+			return n
+		}
+	}
+
+	return nil
+}
+
+// Floor returns the node holding the greatest key less than or equal to key,
+// or nil if there is none.
+func Floor[K cmp.Ordered, V any](root *Node[K, V], key K) *Node[K, V] {
+	var floor *Node[K, V]
+
+	for n := root; n != nil; {
+		switch c := cmp.Compare(key, n.Key); {
+		case c < 0:
+			n = n.Left()
+		case c > 0:
+			floor, n = n, n.Right()
+		default:
+			return n
+		}
+	}
+
+	return floor
+}
+
+// Ceiling returns the node holding the smallest key greater than or equal to
+// key, or nil if there is none.
+func Ceiling[K cmp.Ordered, V any](root *Node[K, V], key K) *Node[K, V] {
+	var ceiling *Node[K, V]
+
+	for n := root; n != nil; {
+		switch c := cmp.Compare(key, n.Key); {
+		case c < 0:
+			ceiling, n = n, n.Left()
+		case c > 0:
+			n = n.Right()
+		default:
+			return n
+		}
+	}
+
+	return ceiling
+}
+
+// Predecessor returns the node holding the greatest key strictly less than
+// key, or nil if there is none. key does not need to be in the subtree.
+func Predecessor[K cmp.Ordered, V any](root *Node[K, V], key K) *Node[K, V] {
+	var predecessor *Node[K, V]
+
+	for n := root; n != nil; {
+		if cmp.Less(n.Key, key) {
+			predecessor, n = n, n.Right()
 		} else {
-			n = n.children[Left]
+			n = n.Left()
 		}
 	}
 
-	var zeroVal V
-	return zeroVal, false
+	return predecessor
+}
+
+// Successor returns the node holding the smallest key strictly greater than
+// key, or nil if there is none. key does not need to be in the subtree.
+func Successor[K cmp.Ordered, V any](root *Node[K, V], key K) *Node[K, V] {
+	var successor *Node[K, V]
+
+	for n := root; n != nil; {
+		if cmp.Less(key, n.Key) {
+			successor, n = n, n.Left()
+		} else {
+			n = n.Right()
+		}
+	}
+
+	return successor
+}
+
+// Rank returns the number of keys of the subtree strictly less than key. key
+// does not need to be in the subtree.
+func Rank[K cmp.Ordered, V any](root *Node[K, V], key K) int {
+	rank := 0
+
+	for n := root; n != nil; {
+		switch c := cmp.Compare(key, n.Key); {
+		case c < 0:
+			n = n.Left()
+		case c > 0:
+			rank += 1 + Size(n.Left())
+			n = n.Right()
+		default:
+			return rank + Size(n.Left())
+		}
+	}
+
+	return rank
+}
+
+// Select returns the node holding the i-th smallest key of the subtree,
+// counting from 0, or nil if i is out of range.
+func Select[K cmp.Ordered, V any](root *Node[K, V], i int) *Node[K, V] {
+	for n := root; n != nil; {
+		switch left := Size(n.Left()); {
+		case i < left:
+			n = n.Left()
+		case i > left:
+			i -= left + 1
+			n = n.Right()
+		default:
+			return n
+		}
+	}
+
+	return nil
+}
+
+// SearchSorted looks up every key of the ascending slice keys in a single
+// traversal: keys are split around each visited node, so the path shared by
+// several lookups is only walked once. It calls found(i, n) for each keys[i]
+// held by a node n of the subtree. keys may contain duplicates.
+func SearchSorted[K cmp.Ordered, V any](root *Node[K, V], keys []K, found func(i int, n *Node[K, V])) {
+	searchSorted(root, keys, 0, found)
+}
+
+func searchSorted[K cmp.Ordered, V any](n *Node[K, V], keys []K, offset int, found func(int, *Node[K, V])) {
+	if n == nil || len(keys) == 0 {
+		return
+	}
+
+	lo, _ := slices.BinarySearch(keys, n.Key)
+
+	hi := lo
+	for ; hi < len(keys) && cmp.Compare(keys[hi], n.Key) == 0; hi++ {
+		found(offset+hi, n)
+	}
+
+	searchSorted(n.Left(), keys[:lo], offset, found)
+	searchSorted(n.Right(), keys[hi:], offset+hi, found)
 }
 
 // SearchMin implements the equivalentof the following recursive implementation.
@@ -96,82 +286,293 @@ func SearchMin[K cmp.Ordered, V any](root *Node[K, V]) *Node[K, V] {
 	}
 }
 
+// SearchMax implements the equivalent of the following recursive implementation.
+//
+//	```go
+//	  if root.Right() == nil {
+//		return root
+//	  }
+//
+//	  return SearchMax(root.Right())
+//	```
+func SearchMax[K cmp.Ordered, V any](root *Node[K, V]) *Node[K, V] {
+	n := root
+	for {
+		if n.Right() == nil {
+			return n
+		}
+		n = n.Right()
+	}
+}
+
 // ------------------------------------------------------------------------------
-// -- INSERTION
+// -- TRAVERSAL
 // ------------------------------------------------------------------------------
 
-func Insert[K cmp.Ordered, V any](root *Node[K, V], key K, value V) *Node[K, V] {
+// InOrder calls fn on each node of the subtree in ascending key order. The
+// traversal stops as soon as fn returns false, in which case InOrder also
+// returns false.
+func InOrder[K cmp.Ordered, V any](root *Node[K, V], fn func(n *Node[K, V]) bool) bool {
 	if root == nil {
-		return NewNode(key, value)
+		return true
 	}
 
-	if key == root.Key {
-		root.Value = value
-	} else {
-		var direction Direction
-		if key < root.Key {
-			direction = Left
-		} else {
-			direction = Right
+	return InOrder(root.Left(), fn) && fn(root) && InOrder(root.Right(), fn)
+}
+
+// InOrderDepth is like InOrder but only visits the nodes at most maxDepth
+// links away from root, passing fn their depth (0 for root). Subtrees below
+// maxDepth are not entered.
+func InOrderDepth[K cmp.Ordered, V any](root *Node[K, V], maxDepth int, fn func(n *Node[K, V], depth int) bool) bool {
+	return inOrderDepth(root, 0, maxDepth, fn)
+}
+
+func inOrderDepth[K cmp.Ordered, V any](n *Node[K, V], depth, maxDepth int, fn func(*Node[K, V], int) bool) bool {
+	if n == nil || depth > maxDepth {
+		return true
+	}
+
+	return inOrderDepth(n.Left(), depth+1, maxDepth, fn) &&
+		fn(n, depth) &&
+		inOrderDepth(n.Right(), depth+1, maxDepth, fn)
+}
+
+// LevelOrder calls fn on each node of the subtree breadth-first: by increasing
+// depth (0 for root), and from left to right within a level. The traversal
+// stops as soon as fn returns false, in which case LevelOrder also returns
+// false.
+func LevelOrder[K cmp.Ordered, V any](root *Node[K, V], fn func(n *Node[K, V], depth int) bool) bool {
+	for level, depth := []*Node[K, V]{root}, 0; len(level) > 0; depth++ {
+		var next []*Node[K, V]
+
+		for _, n := range level {
+			if n == nil {
+				continue
+			}
+
+			if !fn(n, depth) {
+				return false
+			}
+
+			next = append(next, n.Left(), n.Right())
 		}
 
-		root.children[direction] = Insert(root.children[direction], key, value)
+		level = next
 	}
 
-	return FixUp(root)
+	return true
+}
+
+// InOrderRange is like InOrder but only visits the nodes whose key k satisfies
+// both aboveLow(k) and belowHigh(k). aboveLow must hold for every key greater
+// than one it holds for, and belowHigh for every key smaller than one it holds
+// for, so that subtrees outside the range are skipped without being visited.
+func InOrderRange[K cmp.Ordered, V any](
+	root *Node[K, V],
+	aboveLow, belowHigh func(key K) bool,
+	fn func(n *Node[K, V]) bool,
+) bool {
+	if root == nil {
+		return true
+	}
+
+	isAboveLow, isBelowHigh := aboveLow(root.Key), belowHigh(root.Key)
+
+	if isAboveLow && !InOrderRange(root.Left(), aboveLow, belowHigh, fn) {
+		return false
+	}
+
+	if isAboveLow && isBelowHigh && !fn(root) {
+		return false
+	}
+
+	if isBelowHigh {
+		return InOrderRange(root.Right(), aboveLow, belowHigh, fn)
+	}
+
+	return true
+}
+
+// ReverseInOrderRange is like InOrderRange but visits the nodes in descending
+// key order.
+func ReverseInOrderRange[K cmp.Ordered, V any](
+	root *Node[K, V],
+	aboveLow, belowHigh func(key K) bool,
+	fn func(n *Node[K, V]) bool,
+) bool {
+	if root == nil {
+		return true
+	}
+
+	isAboveLow, isBelowHigh := aboveLow(root.Key), belowHigh(root.Key)
+
+	if isBelowHigh && !ReverseInOrderRange(root.Right(), aboveLow, belowHigh, fn) {
+		return false
+	}
+
+	if isAboveLow && isBelowHigh && !fn(root) {
+		return false
+	}
+
+	if isAboveLow {
+		return ReverseInOrderRange(root.Left(), aboveLow, belowHigh, fn)
+	}
+
+	return true
+}
+
+// ------------------------------------------------------------------------------
+// -- INSERTION
+// ------------------------------------------------------------------------------
+
+// Insert inserts key into the subtree, or updates its value if it is already
+// present, and returns the new root of the subtree. The boolean reports
+// whether a new node was created.
+func Insert[K cmp.Ordered, V any](root *Node[K, V], key K, value V) (*Node[K, V], bool) {
+	if root == nil {
+		return NewNode(key, value), true
+	}
+
+	var inserted bool
+
+	switch c := cmp.Compare(key, root.Key); {
+	case c < 0:
+		root.children[Left], inserted = Insert(root.Left(), key, value)
+	case c > 0:
+		root.children[Right], inserted = Insert(root.Right(), key, value)
+	default:
+		root.Value = value
+	}
+
+	return FixUp(root), inserted
 }
 
 // ------------------------------------------------------------------------------
 // -- DELETION
 // ------------------------------------------------------------------------------
 
-func Delete[K cmp.Ordered, V any](root *Node[K, V], key K) *Node[K, V] {
-	if key < root.Key {
+// Delete removes key from the subtree and returns its new root. The boolean
+// reports whether key was found; if it was not, the content of the subtree is
+// left unchanged.
+func Delete[K cmp.Ordered, V any](root *Node[K, V], key K) (*Node[K, V], bool) {
+	if root == nil {
+		return nil, false
+	}
+
+	var deleted bool
+
+	// -- c is the comparison of key against the current root of the subtree,
+	// refreshed whenever a rotation replaces it.
+	c := cmp.Compare(key, root.Key)
+
+	if c < 0 {
+		if root.Left() == nil {
+			return root, false
+		}
+
 		if !IsRed(root.Left()) && !IsRed(root.Left().Left()) {
 			root = MoveRedLeft(root)
 		}
 
-		root.children[Left] = Delete(root.Left(), key)
-		return FixUp(root)
+		root.children[Left], deleted = Delete(root.Left(), key)
+		return FixUp(root), deleted
 	}
 
 	if IsRed(root.Left()) {
 		root = Rotate(root, Right)
+		c = cmp.Compare(key, root.Key)
 	}
 
-	if key == root.Key && root.Right() == nil {
-		return nil
+	if root.Right() == nil {
+		if c == 0 {
+			detach(root)
+			return nil, true
+		}
+
+		return root, false
 	}
 
-	if !IsRed(root.Left()) && !IsRed(root.Right()) {
+	if !IsRed(root.Right()) && !IsRed(root.Right().Left()) {
 		root = MoveRedRight(root)
+		c = cmp.Compare(key, root.Key)
 	}
 
-	if key == root.Key {
-		minNode := SearchMin(root.Right())
-		root.Key = minNode.Key
-		root.Value = minNode.Value
-		root.children[Right] = DeleteMin(root.Right())
+	if c == 0 {
+		// -- the successor node takes the place of root, rather than root taking
+		// the key and value of its successor, so that nodes never trade their
+		// content and pointers to them stay meaningful.
+		right, successor := PopMin(root.Right())
+		successor.children = [2]*Node[K, V]{root.Left(), right}
+		successor.isBlack = root.isBlack
+		detach(root)
 
-		return FixUp(root)
+		return FixUp(successor), true
 	}
 
-	root.children[Right] = Delete(root.Right(), key)
+	root.children[Right], deleted = Delete(root.Right(), key)
 
-	return FixUp(root)
+	return FixUp(root), deleted
 }
 
 func DeleteMin[K cmp.Ordered, V any](root *Node[K, V]) *Node[K, V] {
+	root, _ = PopMin(root)
+	return root
+}
+
+func DeleteMax[K cmp.Ordered, V any](root *Node[K, V]) *Node[K, V] {
+	root, _ = PopMax(root)
+	return root
+}
+
+// PopMin removes the node holding the smallest key of the subtree in a single
+// descent. It returns the new root of the subtree and the removed node.
+func PopMin[K cmp.Ordered, V any](root *Node[K, V]) (*Node[K, V], *Node[K, V]) {
 	if root.Left() == nil {
-		return nil
+		detach(root)
+		return nil, root
 	}
 
 	if !IsRed(root.Left()) && !IsRed(root.Left().Left()) {
 		root = MoveRedLeft(root)
 	}
 
-	root.children[Left] = DeleteMin(root.Left())
-	return FixUp(root)
+	var removed *Node[K, V]
+	root.children[Left], removed = PopMin(root.Left())
+
+	return FixUp(root), removed
+}
+
+// PopMax removes the node holding the largest key of the subtree in a single
+// descent. It returns the new root of the subtree and the removed node.
+func PopMax[K cmp.Ordered, V any](root *Node[K, V]) (*Node[K, V], *Node[K, V]) {
+	if IsRed(root.Left()) {
+		root = Rotate(root, Right)
+	}
+
+	if root.Right() == nil {
+		detach(root)
+		return nil, root
+	}
+
+	if !IsRed(root.Right()) && !IsRed(root.Right().Left()) {
+		root = MoveRedRight(root)
+	}
+
+	var removed *Node[K, V]
+	root.children[Right], removed = PopMax(root.Right())
+
+	return FixUp(root), removed
+}
+
+// detach marks n as removed from its tree.
+func detach[K cmp.Ordered, V any](n *Node[K, V]) {
+	n.children = [2]*Node[K, V]{}
+	n.size = 0
+}
+
+// Detached reports whether n was removed from the tree it belonged to.
+func Detached[K cmp.Ordered, V any](n *Node[K, V]) bool {
+	return n.size == 0
 }
 
 // ------------------------------------------------------------------------------
@@ -232,6 +633,10 @@ func Rotate[K cmp.Ordered, V any](
 	x.isBlack = root.isBlack
 	root.isBlack = false
 
+	// -- the pivot now roots the same set of nodes root did.
+	x.size = root.size
+	updateSize(root)
+
 	return x
 }
 
@@ -255,7 +660,12 @@ func Rotate[K cmp.Ordered, V any](
 // the parent as shown in the figure entitled "Passing a red link up in a LLRB tree"
 // on page 4 of the following paper:
 // - https://sedgewick.io/wp-content/themes/sedgewick/papers/2008LLRB.pdf
+//
+// As every modified subtree is fixed up on the way back to the root, FixUp is
+// also where the subtree size of root is refreshed.
 func FixUp[K cmp.Ordered, V any](root *Node[K, V]) *Node[K, V] {
+	updateSize(root)
+
 	if IsRed(root.Right()) {
 		root = Rotate(root, Left)
 	}
@@ -305,11 +715,276 @@ func MoveRedRight[K cmp.Ordered, V any](root *Node[K, V]) *Node[K, V] {
 
 	if IsRed(root.Left().Left()) {
 		root = Rotate(root, Right)
+
+		FlipColor(root)
 	}
 
 	return root
 }
 
+// ------------------------------------------------------------------------------
+// -- VALIDATION
+// ------------------------------------------------------------------------------
+
+var (
+	// ErrCorrupted is wrapped by every error reporting a tree that violates
+	// the LLRB invariants.
+	ErrCorrupted = errors.New("llrb: corrupted tree")
+	// ErrInvalidColor is the panic value of SetColor when given an unknown
+	// Color.
+	ErrInvalidColor = errors.New("llrb: invalid color")
+)
+
+// Invariant identifies a property every LLRB tree must satisfy.
+type Invariant int
+
+const (
+	// InvariantOrder: keys are in binary search tree order.
+	InvariantOrder Invariant = iota
+	// InvariantBlackRoot: the root is black.
+	InvariantBlackRoot
+	// InvariantLeftLeaning: no node has a red right child.
+	InvariantLeftLeaning
+	// InvariantNoDoubleRed: no red node has a red left child.
+	InvariantNoDoubleRed
+	// InvariantBlackHeight: every path from a node to its leaves crosses the
+	// same number of black nodes.
+	InvariantBlackHeight
+	// InvariantSize: every node records the size of its subtree.
+	InvariantSize
+)
+
+func (i Invariant) String() string {
+	switch i {
+	case InvariantOrder:
+		return "order"
+	case InvariantBlackRoot:
+		return "black root"
+	case InvariantLeftLeaning:
+		return "left-leaning"
+	case InvariantNoDoubleRed:
+		return "no double red"
+	case InvariantBlackHeight:
+		return "black height"
+	case InvariantSize:
+		return "subtree size"
+	default:
+		return fmt.Sprintf("Invariant(%d)", int(i))
+	}
+}
+
+// ViolationError reports the first invariant found violated by Validate and
+// the key of the node where it was detected.
+type ViolationError[K cmp.Ordered] struct {
+	Invariant Invariant
+	Key       K
+}
+
+func (e *ViolationError[K]) Error() string {
+	return fmt.Sprintf("llrb: %s invariant violated at key %v", e.Invariant, e.Key)
+}
+
+// Unwrap makes errors.Is(err, ErrCorrupted) hold for every ViolationError.
+func (e *ViolationError[K]) Unwrap() error {
+	return ErrCorrupted
+}
+
+// Validate checks that the tree rooted at root satisfies every LLRB invariant.
+// It returns nil or a *ViolationError.
+func Validate[K cmp.Ordered, V any](root *Node[K, V]) error {
+	if IsRed(root) {
+		return &ViolationError[K]{Invariant: InvariantBlackRoot, Key: root.Key}
+	}
+
+	_, err := validate(root, nil, nil)
+
+	return err
+}
+
+// validate checks the subtree rooted at n, whose keys must lie strictly
+// between lo and hi when those are not nil. It returns the black height of
+// the subtree.
+func validate[K cmp.Ordered, V any](n *Node[K, V], lo, hi *K) (int, error) {
+	if n == nil {
+		return 0, nil
+	}
+
+	violation := func(invariant Invariant) (int, error) {
+		return 0, &ViolationError[K]{Invariant: invariant, Key: n.Key}
+	}
+
+	if (lo != nil && cmp.Compare(n.Key, *lo) <= 0) || (hi != nil && cmp.Compare(n.Key, *hi) >= 0) {
+		return violation(InvariantOrder)
+	}
+
+	if IsRed(n.Right()) {
+		return violation(InvariantLeftLeaning)
+	}
+
+	if IsRed(n) && IsRed(n.Left()) {
+		return violation(InvariantNoDoubleRed)
+	}
+
+	left, err := validate(n.Left(), lo, &n.Key)
+	if err != nil {
+		return 0, err
+	}
+
+	right, err := validate(n.Right(), &n.Key, hi)
+	if err != nil {
+		return 0, err
+	}
+
+	if left != right {
+		return violation(InvariantBlackHeight)
+	}
+
+	if n.size != 1+Size(n.Left())+Size(n.Right()) {
+		return violation(InvariantSize)
+	}
+
+	if !IsRed(n) {
+		left++
+	}
+
+	return left, nil
+}
+
+// ------------------------------------------------------------------------------
+// -- DUMP
+//
+// The dump format is documented in the llrb package: one "<B|R> <key>" line per
+// node, indented by depth, followed by both children ("-" when nil) unless
+// the node is a leaf.
+// ------------------------------------------------------------------------------
+
+// ErrMalformedDump is wrapped by the errors Parse returns for invalid input.
+var ErrMalformedDump = errors.New("llrb: malformed dump")
+
+const dumpIndent = "  "
+
+// Dump returns the dump of the subtree rooted at root.
+func Dump[K cmp.Ordered, V any](root *Node[K, V]) string {
+	var b strings.Builder
+	dump(&b, root, 0)
+
+	return b.String()
+}
+
+func dump[K cmp.Ordered, V any](b *strings.Builder, n *Node[K, V], depth int) {
+	b.WriteString(strings.Repeat(dumpIndent, depth))
+
+	if n == nil {
+		b.WriteString("-\n")
+		return
+	}
+
+	color := "B"
+	if IsRed(n) {
+		color = "R"
+	}
+
+	fmt.Fprintf(b, "%s %v\n", color, n.Key)
+
+	if n.Left() == nil && n.Right() == nil {
+		return
+	}
+
+	dump(b, n.Left(), depth+1)
+	dump(b, n.Right(), depth+1)
+}
+
+// Parse rebuilds the exact subtree described by a dump, using parseKey to
+// decode keys. Values are left to their zero value. Parse does not check the
+// LLRB invariants, so that corrupted shapes can be reproduced as well.
+func Parse[K cmp.Ordered, V any](s string, parseKey func(string) (K, error)) (*Node[K, V], error) {
+	p := &dumpParser[K, V]{
+		lines:    strings.Split(strings.TrimSuffix(s, "\n"), "\n"),
+		parseKey: parseKey,
+	}
+
+	root, err := p.parse(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.lines) {
+		return nil, p.errorf("unexpected trailing line")
+	}
+
+	return root, nil
+}
+
+type dumpParser[K cmp.Ordered, V any] struct {
+	lines    []string
+	pos      int
+	parseKey func(string) (K, error)
+}
+
+func (p *dumpParser[K, V]) parse(depth int) (*Node[K, V], error) {
+	if p.pos >= len(p.lines) {
+		return nil, fmt.Errorf("%w: unexpected end of input, want a node at depth %d", ErrMalformedDump, depth)
+	}
+
+	body, ok := p.atDepth(depth)
+	if !ok {
+		return nil, p.errorf("want a node at depth %d", depth)
+	}
+
+	if body == "-" {
+		p.pos++
+		return nil, nil
+	}
+
+	color, keyText, ok := strings.Cut(body, " ")
+	if !ok || (color != "B" && color != "R") {
+		return nil, p.errorf("want \"B <key>\", \"R <key>\" or \"-\", got %q", body)
+	}
+
+	key, err := p.parseKey(keyText)
+	if err != nil {
+		return nil, p.errorf("parsing key %q: %v", keyText, err)
+	}
+
+	var zeroVal V
+	n := NewNode(key, zeroVal)
+	n.isBlack = color == "B"
+	p.pos++
+
+	if p.pos == len(p.lines) {
+		return n, nil
+	}
+
+	if _, ok := p.atDepth(depth + 1); !ok {
+		return n, nil
+	}
+
+	for _, direction := range []Direction{Left, Right} {
+		if n.children[direction], err = p.parse(depth + 1); err != nil {
+			return nil, err
+		}
+	}
+
+	updateSize(n)
+
+	return n, nil
+}
+
+// atDepth returns the current line stripped of its indentation if it is
+// indented for exactly the given depth.
+func (p *dumpParser[K, V]) atDepth(depth int) (string, bool) {
+	body, ok := strings.CutPrefix(p.lines[p.pos], strings.Repeat(dumpIndent, depth))
+	if !ok || body == "" || body[0] == ' ' {
+		return "", false
+	}
+
+	return body, true
+}
+
+func (p *dumpParser[K, V]) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: line %d: %s", ErrMalformedDump, p.pos+1, fmt.Sprintf(format, args...))
+}
+
 // ------------------------------------------------------------------------------
 // -- NODE HELPERS
 // ------------------------------------------------------------------------------
@@ -321,13 +996,19 @@ const (
 	ColorRed
 )
 
+// SetColor sets the color of node. Nil nodes are leaves and always black, so
+// SetColor is a no-op on them.
 func SetColor[K cmp.Ordered, V any](node *Node[K, V], color Color) {
+	if node == nil {
+		return
+	}
+
 	switch color {
 	case ColorBlack:
 		node.isBlack = true
 	case ColorRed:
 		node.isBlack = false
 	default:
-		panic("please")
+		panic(fmt.Errorf("%w: %d", ErrInvalidColor, color))
 	}
 }
@@ -15,28 +15,505 @@
  */
 package internal_test
 
-// TODO: write tests
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb/internal"
+)
+
+// build inserts keys in the given order, mapping each key to its double.
+func build(keys ...int) *internal.Node[int, int] {
+	var root *internal.Node[int, int]
+	for _, k := range keys {
+		root, _ = internal.Insert(root, k, 2*k)
+		internal.SetColor(root, internal.ColorBlack)
+	}
+
+	return root
+}
+
+// assertLLRB fails the test if root violates any of the LLRB invariants.
+func assertLLRB(t *testing.T, root *internal.Node[int, int]) {
+	t.Helper()
+
+	if err := internal.Validate(root); err != nil {
+		t.Fatal(err)
+	}
+}
 
 // ------------------------------------------------------------------------------
 // -- Search
 // ------------------------------------------------------------------------------
 
+func TestSearch(t *testing.T) {
+	root := build(5, 3, 8, 1, 4, 7, 9, 2, 6)
+
+	for k := 1; k <= 9; k++ {
+		v, ok := internal.Search(root, k)
+		if !ok || v != 2*k {
+			t.Errorf("Search(%d) = (%d, %t), want (%d, true)", k, v, ok, 2*k)
+		}
+	}
+
+	for _, k := range []int{0, 10, -3} {
+		if _, ok := internal.Search(root, k); ok {
+			t.Errorf("Search(%d) found a key that was never inserted", k)
+		}
+	}
+
+	if _, ok := internal.Search[int, int](nil, 1); ok {
+		t.Error("Search on an empty tree must not find anything")
+	}
+}
+
+// ------------------------------------------------------------------------------
+// -- SearchNode
+// ------------------------------------------------------------------------------
+
+func TestSearchNode(t *testing.T) {
+	root := build(5, 3, 8, 1, 4)
+
+	n := internal.SearchNode(root, 4)
+	if n == nil || n.Key != 4 || n.Value != 8 {
+		t.Fatalf("SearchNode(4) = %+v, want the node holding 4", n)
+	}
+
+	n.Value = 42
+	if v, _ := internal.Search(root, 4); v != 42 {
+		t.Errorf("writes through the returned node are not visible: got %d", v)
+	}
+
+	if n := internal.SearchNode(root, 6); n != nil {
+		t.Errorf("SearchNode(6) = %+v, want nil", n)
+	}
+}
+
+// ------------------------------------------------------------------------------
+// -- Floor / Ceiling
+// ------------------------------------------------------------------------------
+
+func TestFloorCeiling(t *testing.T) {
+	// -- keys 0, 10, ..., 90.
+	var keys []int
+	for _, k := range rand.New(rand.NewSource(1)).Perm(10) {
+		keys = append(keys, 10*k)
+	}
+
+	root := build(keys...)
+
+	for _, tt := range []struct {
+		key, floor, ceiling int
+	}{
+		{key: 40, floor: 40, ceiling: 40},
+		{key: 45, floor: 40, ceiling: 50},
+		{key: -5, floor: -1, ceiling: 0},
+		{key: 0, floor: 0, ceiling: 0},
+		{key: 95, floor: 90, ceiling: -1},
+	} {
+		keyOf := func(n *internal.Node[int, int]) int {
+			if n == nil {
+				return -1
+			}
+
+			return n.Key
+		}
+
+		if got := keyOf(internal.Floor(root, tt.key)); got != tt.floor {
+			t.Errorf("Floor(%d) = %d, want %d", tt.key, got, tt.floor)
+		}
+
+		if got := keyOf(internal.Ceiling(root, tt.key)); got != tt.ceiling {
+			t.Errorf("Ceiling(%d) = %d, want %d", tt.key, got, tt.ceiling)
+		}
+	}
+
+	if internal.Floor[int, int](nil, 1) != nil || internal.Ceiling[int, int](nil, 1) != nil {
+		t.Error("Floor and Ceiling on an empty tree must return nil")
+	}
+}
+
+// ------------------------------------------------------------------------------
+// -- Predecessor / Successor
+// ------------------------------------------------------------------------------
+
+func TestPredecessorSuccessor(t *testing.T) {
+	root := build(rand.New(rand.NewSource(1)).Perm(30)...)
+
+	for key := -2; key < 32; key++ {
+		wantPred, wantSucc := min(key-1, 29), max(key+1, 0)
+
+		if n := internal.Predecessor(root, key); (n == nil) != (wantPred < 0) || (n != nil && n.Key != wantPred) {
+			t.Errorf("Predecessor(%d) = %+v, want %d", key, n, wantPred)
+		}
+
+		if n := internal.Successor(root, key); (n == nil) != (wantSucc > 29) || (n != nil && n.Key != wantSucc) {
+			t.Errorf("Successor(%d) = %+v, want %d", key, n, wantSucc)
+		}
+	}
+}
+
+// ------------------------------------------------------------------------------
+// -- Rank / Select
+// ------------------------------------------------------------------------------
+
+func TestRankSelect(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	// -- store the even keys 0..118, then delete those above 98 so that the
+	// size updates of the deletion paths are exercised too.
+	var keys []int
+	for _, k := range r.Perm(60) {
+		keys = append(keys, 2*k)
+	}
+
+	root := build(keys...)
+	for _, k := range []int{100, 102, 104, 106, 108, 110, 112, 114, 116, 118} {
+		root, _ = internal.Delete(root, k)
+		internal.SetColor(root, internal.ColorBlack)
+	}
+
+	assertLLRB(t, root)
+
+	if size := internal.Size(root); size != 50 {
+		t.Fatalf("Size() = %d, want 50", size)
+	}
+
+	for i := range 50 {
+		if n := internal.Select(root, i); n == nil || n.Key != 2*i {
+			t.Errorf("Select(%d) = %+v, want key %d", i, n, 2*i)
+		}
+
+		if rank := internal.Rank(root, 2*i); rank != i {
+			t.Errorf("Rank(%d) = %d, want %d", 2*i, rank, i)
+		}
+
+		if rank := internal.Rank(root, 2*i+1); rank != i+1 {
+			t.Errorf("Rank(%d) = %d, want %d", 2*i+1, rank, i+1)
+		}
+	}
+
+	if internal.Select(root, -1) != nil || internal.Select(root, 50) != nil {
+		t.Error("Select out of range must return nil")
+	}
+}
+
+// ------------------------------------------------------------------------------
+// -- SearchSorted
+// ------------------------------------------------------------------------------
+
+func TestSearchSorted(t *testing.T) {
+	root := build(rand.New(rand.NewSource(1)).Perm(50)...)
+
+	keys := []int{-1, 0, 0, 7, 25, 48, 49, 50, 99}
+	hits := make([]int, len(keys))
+
+	internal.SearchSorted(root, keys, func(i int, n *internal.Node[int, int]) {
+		if n.Key != keys[i] {
+			t.Errorf("found(%d, %d) for key %d", i, n.Key, keys[i])
+		}
+
+		hits[i]++
+	})
+
+	want := []int{0, 1, 1, 1, 1, 1, 1, 0, 0}
+	for i := range keys {
+		if hits[i] != want[i] {
+			t.Errorf("key %d reported %d times, want %d", keys[i], hits[i], want[i])
+		}
+	}
+}
+
 // ------------------------------------------------------------------------------
 // -- SearchMin
 // ------------------------------------------------------------------------------
 
+func TestSearchMinMax(t *testing.T) {
+	for seed := range int64(10) {
+		root := build(rand.New(rand.NewSource(seed)).Perm(50)...)
+
+		if n := internal.SearchMin(root); n.Key != 0 {
+			t.Errorf("seed %d: SearchMin() = %d, want 0", seed, n.Key)
+		}
+
+		if n := internal.SearchMax(root); n.Key != 49 {
+			t.Errorf("seed %d: SearchMax() = %d, want 49", seed, n.Key)
+		}
+	}
+
+	single := build(7)
+	if internal.SearchMin(single) != single || internal.SearchMax(single) != single {
+		t.Error("the only node of a tree must be both its minimum and maximum")
+	}
+}
+
+// ------------------------------------------------------------------------------
+// -- InOrder
+// ------------------------------------------------------------------------------
+
+func TestInOrder(t *testing.T) {
+	root := build(5, 3, 8, 1, 4, 7, 9, 2, 6)
+
+	var got []int
+	internal.InOrder(root, func(n *internal.Node[int, int]) bool {
+		got = append(got, n.Key)
+		return true
+	})
+
+	for i, k := range got {
+		if k != i+1 {
+			t.Fatalf("InOrder visited %v, want 1..9 in order", got)
+		}
+	}
+
+	if len(got) != 9 {
+		t.Fatalf("InOrder visited %d nodes, want 9", len(got))
+	}
+
+	got = got[:0]
+	completed := internal.InOrder(root, func(n *internal.Node[int, int]) bool {
+		got = append(got, n.Key)
+		return n.Key < 3
+	})
+
+	if completed || len(got) != 3 {
+		t.Errorf("InOrder did not stop early: completed=%t visited=%v", completed, got)
+	}
+}
+
+// ------------------------------------------------------------------------------
+// -- InOrderRange
+// ------------------------------------------------------------------------------
+
+func TestInOrderRange(t *testing.T) {
+	root := build(rand.New(rand.NewSource(1)).Perm(100)...)
+
+	var visited []int
+	internal.InOrderRange(root,
+		func(k int) bool { return k >= 40 },
+		func(k int) bool { return k < 45 },
+		func(n *internal.Node[int, int]) bool {
+			visited = append(visited, n.Key)
+			return true
+		})
+
+	if len(visited) != 5 || visited[0] != 40 || visited[4] != 44 {
+		t.Errorf("InOrderRange visited %v, want [40 41 42 43 44]", visited)
+	}
+
+	visited = visited[:0]
+	completed := internal.InOrderRange(root,
+		func(int) bool { return true },
+		func(k int) bool { return k < 10 },
+		func(n *internal.Node[int, int]) bool {
+			visited = append(visited, n.Key)
+			return n.Key < 2
+		})
+
+	if completed || len(visited) != 3 {
+		t.Errorf("InOrderRange did not stop early: completed=%t visited=%v", completed, visited)
+	}
+}
+
+// ------------------------------------------------------------------------------
+// -- ReverseInOrderRange
+// ------------------------------------------------------------------------------
+
+func TestReverseInOrderRange(t *testing.T) {
+	root := build(rand.New(rand.NewSource(1)).Perm(100)...)
+
+	var visited []int
+	internal.ReverseInOrderRange(root,
+		func(k int) bool { return k >= 40 },
+		func(k int) bool { return k < 45 },
+		func(n *internal.Node[int, int]) bool {
+			visited = append(visited, n.Key)
+			return true
+		})
+
+	if len(visited) != 5 || visited[0] != 44 || visited[4] != 40 {
+		t.Errorf("ReverseInOrderRange visited %v, want [44 43 42 41 40]", visited)
+	}
+
+	visited = visited[:0]
+	completed := internal.ReverseInOrderRange(root,
+		func(int) bool { return true },
+		func(int) bool { return true },
+		func(n *internal.Node[int, int]) bool {
+			visited = append(visited, n.Key)
+			return n.Key > 97
+		})
+
+	if completed || len(visited) != 3 {
+		t.Errorf("ReverseInOrderRange did not stop early: completed=%t visited=%v", completed, visited)
+	}
+}
+
 // ------------------------------------------------------------------------------
 // -- Insert
 // ------------------------------------------------------------------------------
 
+func TestInsert(t *testing.T) {
+	var root *internal.Node[int, int]
+
+	for i, k := range rand.New(rand.NewSource(1)).Perm(100) {
+		var inserted bool
+		root, inserted = internal.Insert(root, k, i)
+		internal.SetColor(root, internal.ColorBlack)
+		assertLLRB(t, root)
+
+		if !inserted {
+			t.Fatalf("Insert(%d) did not report a new node", k)
+		}
+	}
+
+	root, inserted := internal.Insert(root, 42, -1)
+	if inserted {
+		t.Error("Insert of an existing key reported a new node")
+	}
+
+	if v, _ := internal.Search(root, 42); v != -1 {
+		t.Errorf("Insert of an existing key did not update its value: got %d", v)
+	}
+}
+
 // ------------------------------------------------------------------------------
 // -- Delete
 // ------------------------------------------------------------------------------
 
+func TestDelete(t *testing.T) {
+	for seed := range int64(50) {
+		r := rand.New(rand.NewSource(seed))
+		keys := r.Perm(64)
+		root := build(keys...)
+		assertLLRB(t, root)
+
+		r.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+
+		for i, k := range keys {
+			var deleted bool
+			root, deleted = internal.Delete(root, k)
+			internal.SetColor(root, internal.ColorBlack)
+			assertLLRB(t, root)
+
+			if !deleted {
+				t.Fatalf("seed %d: Delete(%d) reported a missing key", seed, k)
+			}
+
+			if _, ok := internal.Search(root, k); ok {
+				t.Fatalf("seed %d: key %d still present after Delete", seed, k)
+			}
+
+			for _, remaining := range keys[i+1:] {
+				if _, ok := internal.Search(root, remaining); !ok {
+					t.Fatalf("seed %d: deleting %d lost key %d", seed, k, remaining)
+				}
+			}
+		}
+
+		if root != nil {
+			t.Fatalf("seed %d: tree not empty after deleting every key", seed)
+		}
+	}
+}
+
+func TestDeleteMissing(t *testing.T) {
+	if root, deleted := internal.Delete[int, int](nil, 1); root != nil || deleted {
+		t.Fatalf("Delete on an empty tree = (%+v, %t), want (nil, false)", root, deleted)
+	}
+
+	for seed := range int64(50) {
+		r := rand.New(rand.NewSource(seed))
+
+		// -- store even keys only, then delete every odd key around them.
+		var keys []int
+		for _, k := range r.Perm(64) {
+			keys = append(keys, 2*k)
+		}
+
+		root := build(keys...)
+
+		for _, k := range r.Perm(65) {
+			var deleted bool
+			root, deleted = internal.Delete(root, 2*k-1)
+			internal.SetColor(root, internal.ColorBlack)
+			assertLLRB(t, root)
+
+			if deleted {
+				t.Fatalf("seed %d: Delete(%d) reported deleting a missing key", seed, 2*k-1)
+			}
+		}
+
+		for _, k := range keys {
+			if v, ok := internal.Search(root, k); !ok || v != 2*k {
+				t.Fatalf("seed %d: Search(%d) = (%d, %t) after deleting missing keys", seed, k, v, ok)
+			}
+		}
+	}
+}
+
 // ------------------------------------------------------------------------------
-// -- DeleteMin
+// -- DeleteMin / DeleteMax
 // ------------------------------------------------------------------------------
 
+func TestDeleteMinMax(t *testing.T) {
+	root := build(rand.New(rand.NewSource(1)).Perm(20)...)
+
+	for i := range 10 {
+		root = internal.DeleteMin(root)
+		internal.SetColor(root, internal.ColorBlack)
+		assertLLRB(t, root)
+
+		root = internal.DeleteMax(root)
+		internal.SetColor(root, internal.ColorBlack)
+		assertLLRB(t, root)
+
+		if root != nil && (internal.SearchMin(root).Key != i+1 || internal.SearchMax(root).Key != 18-i) {
+			t.Fatalf("after %d rounds the tree spans [%d, %d]", i+1, internal.SearchMin(root).Key, internal.SearchMax(root).Key)
+		}
+	}
+
+	if root != nil {
+		t.Fatal("tree not empty after deleting every key")
+	}
+}
+
+// ------------------------------------------------------------------------------
+// -- PopMin / PopMax
+// ------------------------------------------------------------------------------
+
+func TestPopMinPopMax(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		pop  func(*internal.Node[int, int]) (*internal.Node[int, int], *internal.Node[int, int])
+		want func(i int) int
+	}{
+		{name: "PopMin", pop: internal.PopMin[int, int], want: func(i int) int { return i }},
+		{name: "PopMax", pop: internal.PopMax[int, int], want: func(i int) int { return 99 - i }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			root := build(rand.New(rand.NewSource(1)).Perm(100)...)
+
+			for i := range 100 {
+				var removed *internal.Node[int, int]
+				root, removed = tt.pop(root)
+				internal.SetColor(root, internal.ColorBlack)
+				assertLLRB(t, root)
+
+				if removed.Key != tt.want(i) || removed.Value != 2*tt.want(i) {
+					t.Fatalf("pop #%d removed (%d, %d), want key %d", i, removed.Key, removed.Value, tt.want(i))
+				}
+			}
+
+			if root != nil {
+				t.Fatal("tree not empty after popping every key")
+			}
+		})
+	}
+}
+
 // ------------------------------------------------------------------------------
 // -- Rotate
 // ------------------------------------------------------------------------------
@@ -64,3 +541,180 @@ package internal_test
 // ------------------------------------------------------------------------------
 // -- SetColor
 // ------------------------------------------------------------------------------
+
+func TestSetColor(t *testing.T) {
+	n := internal.NewNode(1, 1)
+
+	internal.SetColor(n, internal.ColorBlack)
+	if internal.IsRed(n) {
+		t.Error("node is red after SetColor(ColorBlack)")
+	}
+
+	internal.SetColor(n, internal.ColorRed)
+	if !internal.IsRed(n) {
+		t.Error("node is black after SetColor(ColorRed)")
+	}
+
+	internal.SetColor[int, int](nil, internal.ColorRed)
+	if internal.IsRed[int, int](nil) {
+		t.Error("nil nodes must stay black")
+	}
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, internal.ErrInvalidColor) {
+			t.Errorf("SetColor with an unknown color panicked with %v, want ErrInvalidColor", err)
+		}
+	}()
+
+	internal.SetColor(n, internal.Color(42))
+}
+
+// ------------------------------------------------------------------------------
+// -- Validate
+// ------------------------------------------------------------------------------
+
+func TestValidate(t *testing.T) {
+	if err := internal.Validate[int, int](nil); err != nil {
+		t.Errorf("Validate(nil) = %v, want nil", err)
+	}
+
+	for _, tt := range []struct {
+		name    string
+		corrupt func(root *internal.Node[int, int])
+		want    internal.Invariant
+		key     int
+	}{
+		{
+			name:    "red root",
+			corrupt: func(root *internal.Node[int, int]) { internal.SetColor(root, internal.ColorRed) },
+			want:    internal.InvariantBlackRoot,
+			key:     4,
+		},
+		{
+			name:    "out of order key",
+			corrupt: func(root *internal.Node[int, int]) { root.Left().Key = 5 },
+			want:    internal.InvariantOrder,
+			key:     5,
+		},
+		{
+			name:    "red right link",
+			corrupt: func(root *internal.Node[int, int]) { internal.SetColor(root.Right().Right(), internal.ColorRed) },
+			want:    internal.InvariantLeftLeaning,
+			key:     6,
+		},
+		{
+			name: "double red",
+			corrupt: func(root *internal.Node[int, int]) {
+				internal.SetColor(root.Left(), internal.ColorRed)
+				internal.SetColor(root.Left().Left(), internal.ColorRed)
+			},
+			want: internal.InvariantNoDoubleRed,
+			key:  2,
+		},
+		{
+			name:    "unbalanced black height",
+			corrupt: func(root *internal.Node[int, int]) { internal.SetColor(root.Left().Left(), internal.ColorRed) },
+			want:    internal.InvariantBlackHeight,
+			key:     2,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// -- inserting 1..7 in order yields a perfect, all-black tree rooted at 4.
+			root := build(1, 2, 3, 4, 5, 6, 7)
+			assertLLRB(t, root)
+
+			tt.corrupt(root)
+
+			var violation *internal.ViolationError[int]
+			if err := internal.Validate(root); !errors.As(err, &violation) {
+				t.Fatalf("Validate() = %v, want a *ViolationError", err)
+			}
+
+			if violation.Invariant != tt.want || violation.Key != tt.key {
+				t.Errorf("Validate() = %v, want %s at key %d", violation, tt.want, tt.key)
+			}
+
+			if !errors.Is(violation, internal.ErrCorrupted) {
+				t.Error("a ViolationError must wrap ErrCorrupted")
+			}
+		})
+	}
+}
+
+// ------------------------------------------------------------------------------
+// -- Dump / Parse
+// ------------------------------------------------------------------------------
+
+func TestDump(t *testing.T) {
+	want := `B 4
+  B 2
+    B 1
+    B 3
+  B 6
+    B 5
+    B 7
+`
+	if got := internal.Dump(build(1, 2, 3, 4, 5, 6, 7)); got != want {
+		t.Errorf("Dump() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := internal.Dump(build(2, 1, 3, 0)); got != "B 2\n  B 1\n    R 0\n    -\n  B 3\n" {
+		t.Errorf("Dump() =\n%s", got)
+	}
+
+	if got := internal.Dump[int, int](nil); got != "-\n" {
+		t.Errorf("Dump(nil) = %q, want %q", got, "-\n")
+	}
+}
+
+func TestParse(t *testing.T) {
+	for seed := range int64(20) {
+		root := build(rand.New(rand.NewSource(seed)).Perm(40)...)
+		dump := internal.Dump(root)
+
+		parsed, err := internal.Parse[int, int](dump, strconv.Atoi)
+		if err != nil {
+			t.Fatalf("seed %d: Parse() = %v", seed, err)
+		}
+
+		if got := internal.Dump(parsed); got != dump {
+			t.Fatalf("seed %d: round trip changed the dump:\n%s\nwant\n%s", seed, got, dump)
+		}
+
+		assertLLRB(t, parsed)
+	}
+
+	// -- corrupted shapes are reproduced as is.
+	parsed, err := internal.Parse[int, int]("R 1\n  -\n  R 2\n", strconv.Atoi)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !internal.IsRed(parsed) || !internal.IsRed(parsed.Right()) || parsed.Left() != nil {
+		t.Errorf("Parse() did not rebuild the corrupted shape:\n%s", internal.Dump(parsed))
+	}
+
+	if root, err := internal.Parse[int, int]("-", strconv.Atoi); err != nil || root != nil {
+		t.Errorf("Parse(-) = (%v, %v), want an empty tree", root, err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name, dump string
+	}{
+		{name: "empty input", dump: ""},
+		{name: "unknown color", dump: "X 1\n"},
+		{name: "bad key", dump: "B one\n"},
+		{name: "missing right child", dump: "B 2\n  B 1\n"},
+		{name: "over-indented child", dump: "B 2\n    B 1\n  -\n"},
+		{name: "trailing line", dump: "B 1\nB 2\n"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := internal.Parse[int, int](tt.dump, strconv.Atoi); !errors.Is(err, internal.ErrMalformedDump) {
+				t.Errorf("Parse() = %v, want ErrMalformedDump", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb
+
+import (
+	"cmp"
+	"context"
+	"iter"
+
+	"github.com/alexandremahdhaoui/llrb/internal"
+)
+
+// ------------------------------------------------------------------------------
+// -- ITERATOR INTEROP
+// ------------------------------------------------------------------------------
+
+// Collect builds a tree from the key/value pairs yielded by seq. When seq
+// yields a key more than once, the last value wins.
+func Collect[K cmp.Ordered, V any](seq iter.Seq2[K, V]) *Tree[K, V] {
+	t := &Tree[K, V]{}
+	t.InsertSeq(seq)
+
+	return t
+}
+
+// InsertSeq inserts every key/value pair yielded by seq, overwriting the value
+// of keys already present.
+func (t *Tree[K, V]) InsertSeq(seq iter.Seq2[K, V]) {
+	for key, value := range seq {
+		t.Insert(key, value)
+	}
+}
+
+// DeleteSeq deletes every key yielded by seq. Missing keys are ignored.
+func (t *Tree[K, V]) DeleteSeq(seq iter.Seq[K]) {
+	for key := range seq {
+		t.Delete(key)
+	}
+}
+
+// All returns the entries of the tree in ascending key order, for use with
+// range-over-func:
+//
+//	for key, value := range tree.All() {
+//		...
+//	}
+//
+// The traversal stops as soon as the loop breaks. The tree must not be
+// mutated while the sequence is being consumed.
+func (t *Tree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		internal.InOrder(t.root, func(n *internal.Node[K, V]) bool {
+			return yield(n.Key, n.Value)
+		})
+	}
+}
+
+// Take returns the first n entries of the tree in ascending key order, or all
+// of them if the tree holds fewer. The traversal stops after the n-th entry.
+func (t *Tree[K, V]) Take(n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		taken := 0
+
+		internal.InOrder(t.root, func(node *internal.Node[K, V]) bool {
+			if taken >= n {
+				return false
+			}
+
+			taken++
+
+			return yield(node.Key, node.Value)
+		})
+	}
+}
+
+// Drop returns the entries of the tree in ascending key order, skipping the
+// first n. The first entry yielded is found by rank in O(log n) instead of
+// walking past the skipped ones.
+func (t *Tree[K, V]) Drop(n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		first := internal.Select(t.root, max(n, 0))
+		if first == nil {
+			return
+		}
+
+		for k, v := range t.Range(Included(first.Key), Unbounded[K]()) {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Chunks returns a sequence of consecutive slices of at most n entries, in
+// ascending key order. Each yielded slice is freshly allocated and may be
+// retained by the caller. Chunks panics if n is less than 1.
+func (t *Tree[K, V]) Chunks(n int) iter.Seq[[]Entry[K, V]] {
+	if n < 1 {
+		panic("llrb: Chunks called with n < 1")
+	}
+
+	return func(yield func([]Entry[K, V]) bool) {
+		var chunk []Entry[K, V]
+
+		completed := internal.InOrder(t.root, func(node *internal.Node[K, V]) bool {
+			if chunk == nil {
+				chunk = make([]Entry[K, V], 0, n)
+			}
+
+			chunk = append(chunk, Entry[K, V]{Key: node.Key, Value: node.Value})
+			if len(chunk) < n {
+				return true
+			}
+
+			full := chunk
+			chunk = nil
+
+			return yield(full)
+		})
+
+		if completed && len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// IterChan streams the entries of the tree in ascending key order on the
+// returned channel, which has the given buffer size and is closed once every
+// entry was sent or ctx is done. Consumers that stop reading early must cancel
+// ctx to release the producing goroutine. The tree must not be mutated until
+// the channel is closed.
+func (t *Tree[K, V]) IterChan(ctx context.Context, buffer int) <-chan Entry[K, V] {
+	ch := make(chan Entry[K, V], buffer)
+
+	go func() {
+		defer close(ch)
+
+		internal.InOrder(t.root, func(n *internal.Node[K, V]) bool {
+			select {
+			case ch <- Entry[K, V]{Key: n.Key, Value: n.Value}:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+
+	return ch
+}
+
+// rebuildInterval is the number of entries Rebuild inserts between two
+// progress reports and cancellation checks.
+const rebuildInterval = 1024
+
+// Rebuild replaces the content of the tree with the pairs yielded by seq. The
+// new tree is built aside and swapped in only once seq is exhausted, so the
+// tree keeps its previous content if ctx is done first; Rebuild then returns
+// ctx.Err(). Every rebuildInterval entries, and once at the end, progress is
+// called with the number of entries consumed so far unless it is nil.
+func (t *Tree[K, V]) Rebuild(ctx context.Context, seq iter.Seq2[K, V], progress func(done int)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var (
+		next Tree[K, V]
+		done int
+	)
+
+	for key, value := range seq {
+		next.Insert(key, value)
+		done++
+
+		if done%rebuildInterval != 0 {
+			continue
+		}
+
+		if progress != nil {
+			progress(done)
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	if progress != nil && done%rebuildInterval != 0 {
+		progress(done)
+	}
+
+	t.replace(next.root, next.size)
+
+	return nil
+}
+
+// ------------------------------------------------------------------------------
+// -- SET OPERATIONS OVER SEQUENCES
+// ------------------------------------------------------------------------------
+
+// ContainsAll reports whether every key yielded by seq is in the tree. It
+// stops consuming seq at the first missing key.
+func (t *Tree[K, V]) ContainsAll(seq iter.Seq[K]) bool {
+	for key := range seq {
+		if !t.Contains(key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RemoveAll deletes every key yielded by seq and returns how many entries were
+// actually removed.
+func (t *Tree[K, V]) RemoveAll(seq iter.Seq[K]) int {
+	before := t.size
+	t.DeleteSeq(seq)
+
+	return before - t.size
+}
+
+// IntersectSeq keeps only the entries whose key is yielded by seq. The other
+// entries are deleted in place, so the kept ones stay in their nodes, and
+// their handles and GetRef pointers remain valid.
+func (t *Tree[K, V]) IntersectSeq(seq iter.Seq[K]) {
+	// -- NaN is the one key a map cannot find, hence the separate flag.
+	keep, keepNaN := make(map[K]struct{}), false
+	for key := range seq {
+		if key != key {
+			keepNaN = true
+		} else {
+			keep[key] = struct{}{}
+		}
+	}
+
+	var drop []K
+	internal.InOrder(t.root, func(n *internal.Node[K, V]) bool {
+		if _, ok := keep[n.Key]; !ok && !(keepNaN && n.Key != n.Key) {
+			drop = append(drop, n.Key)
+		}
+
+		return true
+	})
+
+	for _, key := range drop {
+		t.Delete(key)
+	}
+}
@@ -0,0 +1,355 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"maps"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb"
+)
+
+func TestCollect(t *testing.T) {
+	m := map[string]int{"b": 2, "a": 1, "c": 3}
+
+	tree := llrb.Collect(maps.All(m))
+
+	if got := tree.AppendKeysTo(nil); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("keys = %v, want [a b c]", got)
+	}
+
+	for k, want := range m {
+		if v, ok := tree.Search(k); !ok || v != want {
+			t.Errorf("Search(%q) = (%d, %t), want (%d, true)", k, v, ok, want)
+		}
+	}
+
+	dup := func(yield func(int, string) bool) {
+		_ = yield(1, "first") && yield(1, "last")
+	}
+
+	if v, _ := llrb.Collect(dup).Search(1); v != "last" {
+		t.Errorf("duplicate key kept %q, want \"last\"", v)
+	}
+}
+
+func TestInsertSeqDeleteSeq(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{1, 2}, []string{"a", "b"})
+
+	tree.InsertSeq(maps.All(map[int]string{2: "B", 3: "C", 4: "D"}))
+
+	if got := tree.AppendEntriesTo(nil); !slices.Equal(got, []llrb.Entry[int, string]{
+		{Key: 1, Value: "a"}, {Key: 2, Value: "B"}, {Key: 3, Value: "C"}, {Key: 4, Value: "D"},
+	}) {
+		t.Errorf("entries after InsertSeq = %v", got)
+	}
+
+	tree.DeleteSeq(slices.Values([]int{4, 1, 7, 4}))
+
+	if got := tree.AppendKeysTo(nil); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("keys after DeleteSeq = %v, want [2 3]", got)
+	}
+
+	tree.DeleteSeq(slices.Values([]int{2, 3}))
+
+	if got := tree.AppendKeysTo(nil); len(got) != 0 {
+		t.Errorf("keys after deleting everything = %v, want none", got)
+	}
+}
+
+func TestAll(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{3, 1, 2}, []string{"c", "a", "b"})
+
+	var keys []int
+	for k, v := range tree.All() {
+		if v != string(rune('a'+k-1)) {
+			t.Errorf("All() yielded (%d, %q)", k, v)
+		}
+
+		keys = append(keys, k)
+	}
+
+	if !slices.Equal(keys, []int{1, 2, 3}) {
+		t.Errorf("All() keys = %v, want [1 2 3]", keys)
+	}
+
+	visited := 0
+	for range tree.All() {
+		if visited++; visited == 2 {
+			break
+		}
+	}
+
+	if visited != 2 {
+		t.Errorf("All() kept going after the loop broke: %d entries visited", visited)
+	}
+
+	if got := maps.Collect(tree.ReadOnly().All()); len(got) != 3 || got[2] != "b" {
+		t.Errorf("ReadOnly().All() collected %v", got)
+	}
+}
+
+func TestTakeDrop(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{1, 2, 3, 4, 5}, []string{"a", "b", "c", "d", "e"})
+
+	keys := func(seq iter.Seq2[int, string]) []int {
+		var got []int
+		for k := range seq {
+			got = append(got, k)
+		}
+
+		return got
+	}
+
+	for _, tt := range []struct {
+		n                  int
+		wantTake, wantDrop []int
+	}{
+		{n: 0, wantTake: nil, wantDrop: []int{1, 2, 3, 4, 5}},
+		{n: 2, wantTake: []int{1, 2}, wantDrop: []int{3, 4, 5}},
+		{n: 5, wantTake: []int{1, 2, 3, 4, 5}, wantDrop: nil},
+		{n: 9, wantTake: []int{1, 2, 3, 4, 5}, wantDrop: nil},
+		{n: -1, wantTake: nil, wantDrop: []int{1, 2, 3, 4, 5}},
+	} {
+		if got := keys(tree.Take(tt.n)); !slices.Equal(got, tt.wantTake) {
+			t.Errorf("Take(%d) = %v, want %v", tt.n, got, tt.wantTake)
+		}
+
+		if got := keys(tree.Drop(tt.n)); !slices.Equal(got, tt.wantDrop) {
+			t.Errorf("Drop(%d) = %v, want %v", tt.n, got, tt.wantDrop)
+		}
+	}
+
+	// -- yielding again after the loop broke would panic.
+	for range tree.Drop(1) {
+		break
+	}
+}
+
+func TestChunks(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{1, 2, 3, 4, 5, 6, 7}, make([]struct{}, 7))
+
+	var got [][]int
+	for chunk := range tree.Chunks(3) {
+		var keys []int
+		for _, e := range chunk {
+			keys = append(keys, e.Key)
+		}
+
+		got = append(got, keys)
+	}
+
+	want := [][]int{{1, 2, 3}, {4, 5, 6}, {7}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("Chunks(3) = %v, want %v", got, want)
+	}
+
+	count := 0
+	for range tree.Chunks(2) {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+
+	if count != 2 {
+		t.Errorf("breaking out of Chunks yielded %d chunks, want 2", count)
+	}
+
+	for range (&llrb.Tree[int, int]{}).Chunks(4) {
+		t.Error("Chunks on an empty tree must not yield")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Chunks(0) must panic")
+		}
+	}()
+
+	tree.Chunks(0)
+}
+
+func TestIterChan(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{3, 1, 2}, []string{"c", "a", "b"})
+
+	var got []llrb.Entry[int, string]
+	for e := range tree.IterChan(context.Background(), 0) {
+		got = append(got, e)
+	}
+
+	if !slices.Equal(got, []llrb.Entry[int, string]{{Key: 1, Value: "a"}, {Key: 2, Value: "b"}, {Key: 3, Value: "c"}}) {
+		t.Errorf("IterChan streamed %v", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := tree.IterChan(ctx, 0)
+
+	if e := <-ch; e.Key != 1 {
+		t.Fatalf("first entry = %v, want key 1", e)
+	}
+
+	cancel()
+
+	// -- at most the send already racing with cancellation gets through.
+	n := 0
+	for range ch {
+		n++
+	}
+
+	if n > 1 {
+		t.Errorf("received %d entries after cancellation, want at most 1", n)
+	}
+}
+
+func TestContainsAll(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{1, 2, 3}, make([]bool, 3))
+
+	if !tree.ContainsAll(slices.Values([]int{3, 1})) {
+		t.Error("ContainsAll([3 1]) = false, want true")
+	}
+
+	if tree.ContainsAll(slices.Values([]int{1, 4, 2})) {
+		t.Error("ContainsAll([1 4 2]) = true, want false")
+	}
+
+	if !tree.ContainsAll(slices.Values([]int{})) {
+		t.Error("ContainsAll of an empty sequence must be true")
+	}
+}
+
+func TestRemoveAll(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{1, 2, 3, 4}, make([]bool, 4))
+
+	if n := tree.RemoveAll(slices.Values([]int{4, 2, 9, 2})); n != 2 {
+		t.Errorf("RemoveAll removed %d entries, want 2", n)
+	}
+
+	if got := tree.AppendKeysTo(nil); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("keys after RemoveAll = %v, want [1 3]", got)
+	}
+}
+
+func TestIntersectSeq(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{1, 2, 3, 4}, []string{"a", "b", "c", "d"})
+
+	tree.IntersectSeq(slices.Values([]int{4, 0, 2, 2}))
+
+	if got := tree.AppendEntriesTo(nil); !slices.Equal(got, []llrb.Entry[int, string]{
+		{Key: 2, Value: "b"}, {Key: 4, Value: "d"},
+	}) {
+		t.Errorf("entries after IntersectSeq = %v", got)
+	}
+
+	if tree.Len() != 2 {
+		t.Errorf("Len() = %d after IntersectSeq, want 2", tree.Len())
+	}
+
+	// -- kept entries stay in their nodes.
+	ref, _ := tree.GetRef(4)
+	h, _ := tree.SearchHandle(4)
+
+	tree.IntersectSeq(slices.Values([]int{4}))
+	*ref = "D"
+
+	if v, _ := tree.Search(4); v != "D" || !h.Valid() {
+		t.Errorf("Search(4) = %q, handle valid: %t after IntersectSeq kept 4", v, h.Valid())
+	}
+
+	tree.IntersectSeq(slices.Values([]int{}))
+
+	if got := tree.AppendKeysTo(nil); len(got) != 0 {
+		t.Errorf("intersecting with nothing left %v", got)
+	}
+
+	nan := math.NaN()
+	floats := llrb.FromSortedKVs([]float64{nan, 1, 2}, []string{"nan", "one", "two"})
+	floats.IntersectSeq(slices.Values([]float64{2, nan}))
+
+	if keys := floats.AppendKeysTo(nil); len(keys) != 2 || !math.IsNaN(keys[0]) || keys[1] != 2 {
+		t.Errorf("keys after IntersectSeq([2 NaN]) = %v, want [NaN 2]", keys)
+	}
+}
+
+func TestRebuild(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{-1}, []int{-1})
+
+	seq := func(yield func(int, int) bool) {
+		for k := range 2500 {
+			if !yield(k, k) {
+				return
+			}
+		}
+	}
+
+	var reports []int
+	if err := tree.Rebuild(context.Background(), seq, func(done int) { reports = append(reports, done) }); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(reports, []int{1024, 2048, 2500}) {
+		t.Errorf("progress reports = %v, want [1024 2048 2500]", reports)
+	}
+
+	if _, ok := tree.Search(-1); ok {
+		t.Error("Rebuild kept an entry of the previous content")
+	}
+
+	if got := tree.AppendKeysTo(nil); len(got) != 2500 || tree.Len() != 2500 {
+		t.Errorf("tree holds %d keys and Len() = %d, want 2500", len(got), tree.Len())
+	}
+
+	if err := tree.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRebuildCancelled(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{-1}, []int{-1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	consumed := 0
+	seq := func(yield func(int, int) bool) {
+		for k := range 5000 {
+			consumed++
+			if !yield(k, k) {
+				return
+			}
+		}
+	}
+
+	err := tree.Rebuild(ctx, seq, func(int) { cancel() })
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Rebuild() = %v, want context.Canceled", err)
+	}
+
+	if consumed != 1024 {
+		t.Errorf("Rebuild consumed %d entries after cancellation, want 1024", consumed)
+	}
+
+	if got := tree.AppendKeysTo(nil); !slices.Equal(got, []int{-1}) {
+		t.Errorf("cancelled Rebuild changed the tree: %v", got)
+	}
+
+	if err := tree.Rebuild(ctx, seq, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Rebuild with a done context = %v, want context.Canceled", err)
+	}
+}
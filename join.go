@@ -0,0 +1,85 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb
+
+import (
+	"cmp"
+	"iter"
+)
+
+// ------------------------------------------------------------------------------
+// -- JOIN
+//
+// Joins walk two trees side by side in key order, like a sorted merge join,
+// and visit each key of either tree once: O(n + m) without hashing.
+// ------------------------------------------------------------------------------
+
+// Join calls fn for every key present in both a and b, in ascending order.
+func Join[K cmp.Ordered, VA, VB any](a *Tree[K, VA], b *Tree[K, VB], fn func(key K, va VA, vb VB)) {
+	OuterJoin(a, b, func(key K, va VA, inA bool, vb VB, inB bool) {
+		if inA && inB {
+			fn(key, va, vb)
+		}
+	})
+}
+
+// LeftJoin calls fn for every key of a, in ascending order. inB reports
+// whether b holds the key too; when it does not, vb is the zero value.
+func LeftJoin[K cmp.Ordered, VA, VB any](a *Tree[K, VA], b *Tree[K, VB], fn func(key K, va VA, vb VB, inB bool)) {
+	OuterJoin(a, b, func(key K, va VA, inA bool, vb VB, inB bool) {
+		if inA {
+			fn(key, va, vb, inB)
+		}
+	})
+}
+
+// OuterJoin calls fn for every key of a or b, in ascending order. inA and inB
+// report which trees hold the key; the value from a tree that does not is the
+// zero value.
+func OuterJoin[K cmp.Ordered, VA, VB any](
+	a *Tree[K, VA],
+	b *Tree[K, VB],
+	fn func(key K, va VA, inA bool, vb VB, inB bool),
+) {
+	nextA, stopA := iter.Pull2(a.All())
+	defer stopA()
+
+	nextB, stopB := iter.Pull2(b.All())
+	defer stopB()
+
+	var (
+		zeroA VA
+		zeroB VB
+	)
+
+	ka, va, okA := nextA()
+	kb, vb, okB := nextB()
+
+	for okA || okB {
+		switch {
+		case okA && okB && cmp.Compare(ka, kb) == 0:
+			fn(ka, va, true, vb, true)
+			ka, va, okA = nextA()
+			kb, vb, okB = nextB()
+		case okA && (!okB || cmp.Less(ka, kb)):
+			fn(ka, va, true, zeroB, false)
+			ka, va, okA = nextA()
+		default:
+			fn(kb, zeroA, false, vb, true)
+			kb, vb, okB = nextB()
+		}
+	}
+}
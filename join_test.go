@@ -0,0 +1,85 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb"
+)
+
+func joinFixtures() (*llrb.Tree[int, string], *llrb.Tree[int, float64]) {
+	a := llrb.FromSortedKVs([]int{1, 2, 4, 6}, []string{"a", "b", "d", "f"})
+	b := llrb.FromSortedKVs([]int{2, 3, 4, 7}, []float64{0.2, 0.3, 0.4, 0.7})
+
+	return a, b
+}
+
+func TestJoin(t *testing.T) {
+	a, b := joinFixtures()
+
+	var got []string
+	llrb.Join(a, b, func(k int, va string, vb float64) {
+		got = append(got, fmt.Sprintf("%d %s %g", k, va, vb))
+	})
+
+	if want := []string{"2 b 0.2", "4 d 0.4"}; !slices.Equal(got, want) {
+		t.Errorf("Join visited %q, want %q", got, want)
+	}
+}
+
+func TestLeftJoin(t *testing.T) {
+	a, b := joinFixtures()
+
+	var got []string
+	llrb.LeftJoin(a, b, func(k int, va string, vb float64, inB bool) {
+		got = append(got, fmt.Sprintf("%d %s %g %t", k, va, vb, inB))
+	})
+
+	if want := []string{"1 a 0 false", "2 b 0.2 true", "4 d 0.4 true", "6 f 0 false"}; !slices.Equal(got, want) {
+		t.Errorf("LeftJoin visited %q, want %q", got, want)
+	}
+}
+
+func TestOuterJoin(t *testing.T) {
+	a, b := joinFixtures()
+
+	var got []string
+	llrb.OuterJoin(a, b, func(k int, va string, inA bool, vb float64, inB bool) {
+		got = append(got, fmt.Sprintf("%d %s %t %g %t", k, va, inA, vb, inB))
+	})
+
+	want := []string{
+		"1 a true 0 false",
+		"2 b true 0.2 true",
+		"3  false 0.3 true",
+		"4 d true 0.4 true",
+		"6 f true 0 false",
+		"7  false 0.7 true",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("OuterJoin visited %q, want %q", got, want)
+	}
+
+	n := 0
+	llrb.OuterJoin(&llrb.Tree[int, int]{}, &llrb.Tree[int, int]{}, func(int, int, bool, int, bool) { n++ })
+
+	if n != 0 {
+		t.Errorf("OuterJoin of empty trees visited %d keys", n)
+	}
+}
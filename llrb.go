@@ -17,6 +17,9 @@ package llrb
 
 import (
 	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
 
 	"github.com/alexandremahdhaoui/llrb/internal"
 )
@@ -30,18 +33,494 @@ import (
 
 type Tree[K cmp.Ordered, V any] struct {
 	root *internal.Node[K, V]
+	size int
+
+	// lastAccess is the node found by the latest lookup, checked before
+	// descending when accessCache is set.
+	lastAccess  *internal.Node[K, V]
+	accessCache bool
+
+	// generation counts the calls to replace, so handles can tell whether the
+	// content they were obtained from is still in place.
+	generation uint64
+}
+
+// Len returns the number of entries in the tree in O(1).
+func (t *Tree[K, V]) Len() int {
+	return t.size
 }
 
 func (t *Tree[K, V]) Search(key K) (V, bool) {
-	return internal.Search(t.root, key)
+	if n := t.lookup(key); n != nil {
+		return n.Value, true
+	}
+
+	var zeroVal V
+	return zeroVal, false
+}
+
+// SetAccessCache enables or disables the access cache. When enabled, the tree
+// remembers the node found by its latest lookup and answers repeated lookups
+// of the same key without descending, which pays off under strong temporal
+// locality. Lookups then write to the tree: a tree with the cache enabled must
+// not be read concurrently, even through a ReadOnlyTree.
+func (t *Tree[K, V]) SetAccessCache(enabled bool) {
+	t.accessCache, t.lastAccess = enabled, nil
+}
+
+// lookup returns the node holding key, or nil, going through the access cache
+// when it is enabled. Nodes keep their key for as long as they are in the
+// tree, so a cached node that was not removed since is still the right one.
+func (t *Tree[K, V]) lookup(key K) *internal.Node[K, V] {
+	if !t.accessCache {
+		return internal.SearchNode(t.root, key)
+	}
+
+	if n := t.lastAccess; n != nil && cmp.Compare(n.Key, key) == 0 && !internal.Detached(n) {
+		return n
+	}
+
+	n := internal.SearchNode(t.root, key)
+	if n != nil {
+		t.lastAccess = n
+	}
+
+	return n
+}
+
+// replace swaps in new content for the whole tree, invalidating every handle.
+func (t *Tree[K, V]) replace(root *internal.Node[K, V], size int) {
+	t.root, t.size, t.lastAccess = root, size, nil
+	t.generation++
+}
+
+// GetOrDefault returns the value stored under key, or def if key is missing.
+func (t *Tree[K, V]) GetOrDefault(key K, def V) V {
+	if n := t.lookup(key); n != nil {
+		return n.Value
+	}
+
+	return def
+}
+
+// GetRef returns a pointer to the value stored under key, through which it can
+// be updated in place. Entries never move between nodes, so the pointer stays
+// valid until key is removed from the tree, or until the whole content of the
+// tree is replaced (Rebuild, UnmarshalYAML); writing through it afterwards has
+// no effect on the tree. The boolean is false if key is missing.
+func (t *Tree[K, V]) GetRef(key K) (*V, bool) {
+	if n := t.lookup(key); n != nil {
+		return &n.Value, true
+	}
+
+	return nil, false
+}
+
+// Contains reports whether key is in the tree, without copying its value.
+func (t *Tree[K, V]) Contains(key K) bool {
+	return t.lookup(key) != nil
+}
+
+// Min returns the entry with the smallest key. The boolean is false if the
+// tree is empty.
+func (t *Tree[K, V]) Min() (K, V, bool) {
+	if t.root == nil {
+		return entryOf[K, V](nil)
+	}
+
+	return entryOf(internal.SearchMin(t.root))
+}
+
+// Max returns the entry with the largest key. The boolean is false if the
+// tree is empty.
+func (t *Tree[K, V]) Max() (K, V, bool) {
+	if t.root == nil {
+		return entryOf[K, V](nil)
+	}
+
+	return entryOf(internal.SearchMax(t.root))
 }
 
 func (t *Tree[K, V]) Insert(key K, value V) {
-	t.root = internal.Insert(t.root, key, value)
+	var inserted bool
+	t.root, inserted = internal.Insert(t.root, key, value)
 	internal.SetColor(t.root, internal.ColorBlack)
+
+	if inserted {
+		t.size++
+	}
+
+	t.check()
 }
 
+// Delete removes key from the tree. Deleting a missing key is a no-op.
 func (t *Tree[K, V]) Delete(key K) {
-	t.root = internal.Delete(t.root, key)
+	var deleted bool
+	t.root, deleted = internal.Delete(t.root, key)
+	internal.SetColor(t.root, internal.ColorBlack)
+
+	if deleted {
+		t.size--
+	}
+
+	t.check()
+}
+
+// PopMin removes the entry with the smallest key and returns it. The boolean
+// is false if the tree is empty.
+func (t *Tree[K, V]) PopMin() (K, V, bool) {
+	if t.root == nil {
+		return entryOf[K, V](nil)
+	}
+
+	var n *internal.Node[K, V]
+	t.root, n = internal.PopMin(t.root)
 	internal.SetColor(t.root, internal.ColorBlack)
+	t.size--
+	t.check()
+
+	return entryOf(n)
+}
+
+// PopMax removes the entry with the largest key and returns it. The boolean
+// is false if the tree is empty.
+func (t *Tree[K, V]) PopMax() (K, V, bool) {
+	if t.root == nil {
+		return entryOf[K, V](nil)
+	}
+
+	var n *internal.Node[K, V]
+	t.root, n = internal.PopMax(t.root)
+	internal.SetColor(t.root, internal.ColorBlack)
+	t.size--
+	t.check()
+
+	return entryOf(n)
+}
+
+// Floor returns the entry with the greatest key less than or equal to key.
+// The boolean is false if there is no such entry.
+func (t *Tree[K, V]) Floor(key K) (K, V, bool) {
+	return entryOf(internal.Floor(t.root, key))
+}
+
+// Ceiling returns the entry with the smallest key greater than or equal to
+// key. The boolean is false if there is no such entry.
+func (t *Tree[K, V]) Ceiling(key K) (K, V, bool) {
+	return entryOf(internal.Ceiling(t.root, key))
+}
+
+// Predecessor returns the entry with the greatest key strictly less than key,
+// which does not need to be in the tree. The boolean is false if there is no
+// such entry.
+func (t *Tree[K, V]) Predecessor(key K) (K, V, bool) {
+	return entryOf(internal.Predecessor(t.root, key))
+}
+
+// Successor returns the entry with the smallest key strictly greater than key,
+// which does not need to be in the tree. The boolean is false if there is no
+// such entry.
+func (t *Tree[K, V]) Successor(key K) (K, V, bool) {
+	return entryOf(internal.Successor(t.root, key))
+}
+
+// Nearest returns the entry whose key is closest to key, which does not need to
+// be in the tree. On a tie between a smaller and a greater key, the smaller
+// one wins. A NaN key is only nearest to NaN, or when it is the sole key of
+// the tree. The boolean is false if the tree is empty. Distance needs
+// arithmetic on keys, hence a function over Number keys rather than a method.
+func Nearest[K Number, V any](t *Tree[K, V], key K) (K, V, bool) {
+	floor, ceiling := internal.Floor(t.root, key), internal.Ceiling(t.root, key)
+
+	switch {
+	case floor == nil:
+		return entryOf(ceiling)
+	case ceiling == nil:
+		return entryOf(floor)
+	case ceilingIsCloser(key, floor.Key, ceiling.Key):
+		return entryOf(ceiling)
+	default:
+		return entryOf(floor)
+	}
+}
+
+// ceilingIsCloser reports whether ceiling is strictly closer to key than floor,
+// given floor <= key <= ceiling, without overflowing on extreme keys.
+func ceilingIsCloser[K Number](key, floor, ceiling K) bool {
+	if half := K(1); half/2 != 0 {
+		// -- NaN sorts first, so only floor can be NaN for a numeric key:
+		// treat it as infinitely far rather than letting the comparison
+		// below fail and pick it.
+		if isNaN(floor) {
+			return !isNaN(key)
+		}
+
+		// -- floating-point keys: float64 holds any float32 difference.
+		return float64(ceiling)-float64(key) < float64(key)-float64(floor)
+	}
+
+	// -- integer keys: both distances fit in a uint64, and computing them
+	// modulo 2^64 yields their exact value even for signed keys.
+	return uint64(ceiling)-uint64(key) < uint64(key)-uint64(floor)
+}
+
+// Rank returns the number of keys in the tree strictly less than key, i.e. the
+// position key has or would have in sorted order. It runs in O(log n).
+func (t *Tree[K, V]) Rank(key K) int {
+	return internal.Rank(t.root, key)
+}
+
+// Select returns the entry with the i-th smallest key, counting from 0. The
+// boolean is false if i is not in [0, Len()). It runs in O(log n).
+func (t *Tree[K, V]) Select(i int) (K, V, bool) {
+	return entryOf(internal.Select(t.root, i))
+}
+
+// CountRange returns the number of keys k with lo <= k < hi in O(log n),
+// without visiting the entries in between. It returns 0 if hi <= lo.
+func (t *Tree[K, V]) CountRange(lo, hi K) int {
+	return max(t.Rank(hi)-t.Rank(lo), 0)
+}
+
+// Quantile returns the entry at quantile q of the keys, where q is in [0, 1]:
+// the entry of rank floor(q * (Len() - 1)), so that 0 and 1 select the
+// smallest and largest keys. The boolean is false if the tree is empty or q is
+// out of range. It runs in O(log n).
+func (t *Tree[K, V]) Quantile(q float64) (K, V, bool) {
+	if t.size == 0 || !(q >= 0 && q <= 1) {
+		return entryOf[K, V](nil)
+	}
+
+	return t.Select(int(q * float64(t.size-1)))
+}
+
+// Median returns the entry at quantile 0.5. With an even number of entries it
+// is the lower of the two middle entries. The boolean is false if the tree is
+// empty.
+func (t *Tree[K, V]) Median() (K, V, bool) {
+	return t.Quantile(0.5)
+}
+
+// RandomKey returns an entry chosen uniformly at random with rng, in
+// O(log n). The boolean is false if the tree is empty.
+func (t *Tree[K, V]) RandomKey(rng *rand.Rand) (K, V, bool) {
+	if t.size == 0 {
+		return entryOf[K, V](nil)
+	}
+
+	return t.Select(rng.IntN(t.size))
+}
+
+// DeleteAt removes the entry with the i-th smallest key, counting from 0, and
+// returns it. The boolean is false, and the tree left untouched, if i is not
+// in [0, Len()).
+func (t *Tree[K, V]) DeleteAt(i int) (K, V, bool) {
+	key, value, ok := t.Select(i)
+	if ok {
+		t.Delete(key)
+	}
+
+	return key, value, ok
+}
+
+// DeleteMin removes the entry with the smallest key. It is a no-op on an
+// empty tree.
+func (t *Tree[K, V]) DeleteMin() {
+	t.PopMin()
+}
+
+// DeleteMax removes the entry with the largest key. It is a no-op on an empty
+// tree.
+func (t *Tree[K, V]) DeleteMax() {
+	t.PopMax()
+}
+
+// Rekey moves the value stored under oldKey to newKey. It returns false and
+// leaves the tree untouched if oldKey is missing or newKey is already taken.
+func (t *Tree[K, V]) Rekey(oldKey, newKey K) bool {
+	return t.RekeyFunc(oldKey, newKey, nil)
+}
+
+// RekeyFunc is like Rekey, but when newKey already exists it stores
+// merge(moved, existing) under newKey instead of failing. A nil merge makes
+// RekeyFunc behave like Rekey.
+func (t *Tree[K, V]) RekeyFunc(oldKey, newKey K, merge func(moved, existing V) V) bool {
+	value, ok := t.Search(oldKey)
+	if !ok {
+		return false
+	}
+
+	if cmp.Compare(oldKey, newKey) == 0 {
+		return true
+	}
+
+	if existing, ok := t.Search(newKey); ok {
+		if merge == nil {
+			return false
+		}
+
+		value = merge(value, existing)
+	}
+
+	t.Delete(oldKey)
+	t.Insert(newKey, value)
+
+	return true
+}
+
+// SwapValues exchanges the values stored under k1 and k2. It returns false and
+// leaves the tree untouched unless both keys are present.
+func (t *Tree[K, V]) SwapValues(k1, k2 K) bool {
+	n1, n2 := internal.SearchNode(t.root, k1), internal.SearchNode(t.root, k2)
+	if n1 == nil || n2 == nil {
+		return false
+	}
+
+	n1.Value, n2.Value = n2.Value, n1.Value
+
+	return true
+}
+
+// Partition splits the entries of the tree in a single ordered pass: entries
+// for which pred returns true go to matching, the others to rest. The tree
+// itself is left untouched.
+func (t *Tree[K, V]) Partition(pred func(key K, value V) bool) (matching, rest *Tree[K, V]) {
+	matching, rest = &Tree[K, V]{}, &Tree[K, V]{}
+
+	internal.InOrder(t.root, func(n *internal.Node[K, V]) bool {
+		if pred(n.Key, n.Value) {
+			matching.Insert(n.Key, n.Value)
+		} else {
+			rest.Insert(n.Key, n.Value)
+		}
+
+		return true
+	})
+
+	return matching, rest
+}
+
+// CopyRange inserts every entry of the tree whose key k satisfies lo <= k < hi
+// into dst, in a single ordered pass, and returns the number of entries
+// copied. Entries already in dst are overwritten. dst must not be t.
+func (t *Tree[K, V]) CopyRange(dst *Tree[K, V], lo, hi K) int {
+	copied := 0
+
+	internal.InOrderRange(t.root,
+		func(key K) bool { return cmp.Compare(key, lo) >= 0 },
+		func(key K) bool { return cmp.Compare(key, hi) < 0 },
+		func(n *internal.Node[K, V]) bool {
+			dst.Insert(n.Key, n.Value)
+			copied++
+
+			return true
+		})
+
+	return copied
+}
+
+// ScanAround returns, in ascending key order, up to n entries with a key
+// smaller than key, the entry stored under key if any, and up to n entries
+// with a key greater than key. key does not need to be in the tree.
+func (t *Tree[K, V]) ScanAround(key K, n int) []Entry[K, V] {
+	var before, after []Entry[K, V]
+
+	always := func(K) bool { return true }
+	collect := func(dst *[]Entry[K, V], limit int) func(*internal.Node[K, V]) bool {
+		return func(node *internal.Node[K, V]) bool {
+			if len(*dst) >= limit {
+				return false
+			}
+
+			*dst = append(*dst, Entry[K, V]{Key: node.Key, Value: node.Value})
+
+			return true
+		}
+	}
+
+	internal.ReverseInOrderRange(t.root, always, func(k K) bool { return cmp.Less(k, key) }, collect(&before, n))
+
+	limit := n
+	if internal.SearchNode(t.root, key) != nil {
+		limit++
+	}
+
+	internal.InOrderRange(t.root, func(k K) bool { return !cmp.Less(k, key) }, always, collect(&after, limit))
+
+	slices.Reverse(before)
+
+	return append(before, after...)
+}
+
+// MultiGet looks up all keys at once. values[i] and found[i] hold the result
+// for keys[i], as Search would return it. The keys are sorted (in a copy) so
+// that a single traversal resolves them all, sharing the descent through the
+// top of the tree between neighboring keys.
+func (t *Tree[K, V]) MultiGet(keys []K) (values []V, found []bool) {
+	values, found = make([]V, len(keys)), make([]bool, len(keys))
+
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+
+	slices.SortFunc(order, func(a, b int) int { return cmp.Compare(keys[a], keys[b]) })
+
+	sorted := make([]K, len(keys))
+	for i, j := range order {
+		sorted[i] = keys[j]
+	}
+
+	internal.SearchSorted(t.root, sorted, func(i int, n *internal.Node[K, V]) {
+		values[order[i]], found[order[i]] = n.Value, true
+	})
+
+	return values, found
+}
+
+// entryOf returns the key and value of n, or zero values and false if n is
+// nil.
+func entryOf[K cmp.Ordered, V any](n *internal.Node[K, V]) (K, V, bool) {
+	if n == nil {
+		var zeroKey K
+		var zeroVal V
+		return zeroKey, zeroVal, false
+	}
+
+	return n.Key, n.Value, true
+}
+
+// ------------------------------------------------------------------------------
+// -- VALIDATION
+// ------------------------------------------------------------------------------
+
+// ErrCorrupted is wrapped by every error reporting a tree that violates the
+// LLRB invariants, such as those returned by Validate.
+var ErrCorrupted = internal.ErrCorrupted
+
+// ViolationError reports which LLRB invariant a corrupted tree breaks and at
+// which key.
+type ViolationError[K cmp.Ordered] = internal.ViolationError[K]
+
+// Validate checks every LLRB invariant over the whole tree and returns nil or
+// a *ViolationError. It runs in O(n).
+func (t *Tree[K, V]) Validate() error {
+	return internal.Validate(t.root)
+}
+
+// check panics with the violation error if the tree is corrupted. It is a
+// no-op unless the package is built with the llrbdebug tag, in which case
+// every public mutation validates the whole tree.
+func (t *Tree[K, V]) check() {
+	if !debug {
+		return
+	}
+
+	if err := t.Validate(); err != nil {
+		panic(err)
+	}
+
+	if n := internal.Size(t.root); n != t.size {
+		panic(fmt.Sprintf("llrb: tree holds %d entries but Len reports %d", n, t.size))
+	}
 }
@@ -0,0 +1,782 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb"
+)
+
+func TestPopMinPopMax(t *testing.T) {
+	tree := &llrb.Tree[int, string]{}
+
+	if _, _, ok := tree.PopMin(); ok {
+		t.Error("PopMin on an empty tree must report false")
+	}
+
+	if _, _, ok := tree.PopMax(); ok {
+		t.Error("PopMax on an empty tree must report false")
+	}
+
+	for _, k := range []int{3, 1, 4, 5, 9, 2, 6} {
+		tree.Insert(k, string(rune('a'+k)))
+	}
+
+	if k, v, ok := tree.PopMin(); !ok || k != 1 || v != "b" {
+		t.Errorf("PopMin() = (%d, %q, %t), want (1, \"b\", true)", k, v, ok)
+	}
+
+	if k, v, ok := tree.PopMax(); !ok || k != 9 || v != "j" {
+		t.Errorf("PopMax() = (%d, %q, %t), want (9, \"j\", true)", k, v, ok)
+	}
+
+	for _, k := range []int{1, 9} {
+		if _, ok := tree.Search(k); ok {
+			t.Errorf("key %d still present after being popped", k)
+		}
+	}
+
+	var got []int
+	for {
+		k, _, ok := tree.PopMin()
+		if !ok {
+			break
+		}
+
+		got = append(got, k)
+	}
+
+	if len(got) != 5 || got[0] != 2 || got[4] != 6 {
+		t.Errorf("draining with PopMin returned %v, want [2 3 4 5 6]", got)
+	}
+}
+
+func TestRekey(t *testing.T) {
+	tree := &llrb.Tree[string, int]{}
+	tree.Insert("a", 1)
+	tree.Insert("b", 2)
+
+	if !tree.Rekey("a", "c") {
+		t.Fatal("Rekey(a, c) failed")
+	}
+
+	if _, ok := tree.Search("a"); ok {
+		t.Error("old key still present after Rekey")
+	}
+
+	if v, ok := tree.Search("c"); !ok || v != 1 {
+		t.Errorf("Search(c) = (%d, %t), want (1, true)", v, ok)
+	}
+
+	if tree.Rekey("missing", "d") {
+		t.Error("Rekey of a missing key must fail")
+	}
+
+	if tree.Rekey("b", "c") {
+		t.Error("Rekey onto an existing key must fail")
+	}
+
+	if v, _ := tree.Search("b"); v != 2 {
+		t.Error("failed Rekey must leave the tree untouched")
+	}
+
+	if !tree.Rekey("b", "b") {
+		t.Error("Rekey onto the same key must succeed")
+	}
+
+	sum := func(moved, existing int) int { return moved + existing }
+	if !tree.RekeyFunc("b", "c", sum) {
+		t.Fatal("RekeyFunc(b, c) failed")
+	}
+
+	if v, _ := tree.Search("c"); v != 3 {
+		t.Errorf("merged value = %d, want 3", v)
+	}
+
+	if _, ok := tree.Search("b"); ok {
+		t.Error("old key still present after RekeyFunc")
+	}
+}
+
+func TestSwapValues(t *testing.T) {
+	tree := &llrb.Tree[int, string]{}
+	tree.Insert(1, "one")
+	tree.Insert(2, "two")
+
+	if !tree.SwapValues(1, 2) {
+		t.Fatal("SwapValues(1, 2) failed")
+	}
+
+	if v, _ := tree.Search(1); v != "two" {
+		t.Errorf("Search(1) = %q, want \"two\"", v)
+	}
+
+	if v, _ := tree.Search(2); v != "one" {
+		t.Errorf("Search(2) = %q, want \"one\"", v)
+	}
+
+	if tree.SwapValues(1, 3) {
+		t.Error("SwapValues with a missing key must fail")
+	}
+
+	if v, _ := tree.Search(1); v != "two" {
+		t.Error("failed SwapValues must leave the tree untouched")
+	}
+
+	if !tree.SwapValues(1, 1) {
+		t.Error("SwapValues of a key with itself must succeed")
+	}
+}
+
+func TestPartition(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{1, 2, 3, 4, 5, 6}, []int{10, 20, 30, 40, 50, 60})
+
+	even, odd := tree.Partition(func(k, _ int) bool { return k%2 == 0 })
+
+	if got := even.AppendEntriesTo(nil); !slices.Equal(got, []llrb.Entry[int, int]{
+		{Key: 2, Value: 20}, {Key: 4, Value: 40}, {Key: 6, Value: 60},
+	}) {
+		t.Errorf("matching = %v", got)
+	}
+
+	if got := odd.AppendKeysTo(nil); !slices.Equal(got, []int{1, 3, 5}) {
+		t.Errorf("rest = %v, want [1 3 5]", got)
+	}
+
+	if got := tree.AppendKeysTo(nil); len(got) != 6 {
+		t.Errorf("Partition modified the source tree: %v", got)
+	}
+
+	none, all := tree.Partition(func(int, int) bool { return false })
+	if none.AppendKeysTo(nil) != nil || len(all.AppendKeysTo(nil)) != 6 {
+		t.Error("Partition with an always-false predicate must put everything in rest")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tree := &llrb.Tree[int, int]{}
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Validate() on an empty tree = %v", err)
+	}
+
+	for i := range 200 {
+		tree.Insert((i*7919)%200, i)
+	}
+
+	for i := range 150 {
+		tree.Delete((i * 13) % 200)
+	}
+
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+}
+
+func TestCopyRange(t *testing.T) {
+	src := llrb.FromSortedKVs([]int{1, 2, 3, 4, 5}, []string{"a", "b", "c", "d", "e"})
+	dst := llrb.FromSortedKVs([]int{0, 3}, []string{"z", "old"})
+
+	if n := src.CopyRange(dst, 2, 5); n != 3 {
+		t.Errorf("CopyRange copied %d entries, want 3", n)
+	}
+
+	if got := dst.AppendEntriesTo(nil); !slices.Equal(got, []llrb.Entry[int, string]{
+		{Key: 0, Value: "z"}, {Key: 2, Value: "b"}, {Key: 3, Value: "c"}, {Key: 4, Value: "d"},
+	}) {
+		t.Errorf("dst entries = %v", got)
+	}
+
+	if got := src.AppendKeysTo(nil); len(got) != 5 {
+		t.Errorf("CopyRange modified the source: %v", got)
+	}
+
+	if n := src.CopyRange(dst, 4, 2); n != 0 {
+		t.Errorf("CopyRange over an empty range copied %d entries", n)
+	}
+}
+
+func TestDeleteMissing(t *testing.T) {
+	tree := &llrb.Tree[int, int]{}
+	tree.Delete(1)
+
+	tree.InsertSeq(func(yield func(int, int) bool) {
+		for k := 0; k < 20; k += 2 {
+			if !yield(k, k) {
+				return
+			}
+		}
+	})
+
+	for k := -1; k < 21; k += 2 {
+		tree.Delete(k)
+	}
+
+	if err := tree.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := tree.AppendKeysTo(nil); len(got) != 10 {
+		t.Errorf("deleting missing keys changed the content: %v", got)
+	}
+}
+
+func TestScanAround(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{10, 20, 30, 40, 50, 60}, []int{1, 2, 3, 4, 5, 6})
+
+	keys := func(entries []llrb.Entry[int, int]) []int {
+		var out []int
+		for _, e := range entries {
+			out = append(out, e.Key)
+		}
+
+		return out
+	}
+
+	for _, tt := range []struct {
+		key, n int
+		want   []int
+	}{
+		{key: 30, n: 1, want: []int{20, 30, 40}},
+		{key: 35, n: 2, want: []int{20, 30, 40, 50}},
+		{key: 10, n: 2, want: []int{10, 20, 30}},
+		{key: 5, n: 1, want: []int{10}},
+		{key: 99, n: 3, want: []int{40, 50, 60}},
+		{key: 30, n: 0, want: []int{30}},
+		{key: 35, n: 10, want: []int{10, 20, 30, 40, 50, 60}},
+	} {
+		if got := keys(tree.ScanAround(tt.key, tt.n)); !slices.Equal(got, tt.want) {
+			t.Errorf("ScanAround(%d, %d) = %v, want %v", tt.key, tt.n, got, tt.want)
+		}
+	}
+
+	if got := (&llrb.Tree[int, int]{}).ScanAround(1, 3); len(got) != 0 {
+		t.Errorf("ScanAround on an empty tree = %v", got)
+	}
+}
+
+func TestMultiGet(t *testing.T) {
+	tree := llrb.FromSortedKVs([]string{"a", "b", "c", "d"}, []int{1, 2, 3, 4})
+
+	values, found := tree.MultiGet([]string{"d", "x", "a", "c", "a"})
+
+	if !slices.Equal(values, []int{4, 0, 1, 3, 1}) {
+		t.Errorf("values = %v, want [4 0 1 3 1]", values)
+	}
+
+	if !slices.Equal(found, []bool{true, false, true, true, true}) {
+		t.Errorf("found = %v", found)
+	}
+
+	if values, found := tree.MultiGet(nil); len(values) != 0 || len(found) != 0 {
+		t.Errorf("MultiGet(nil) = (%v, %v)", values, found)
+	}
+}
+
+func TestLen(t *testing.T) {
+	tree := &llrb.Tree[int, int]{}
+	if tree.Len() != 0 {
+		t.Errorf("Len() of an empty tree = %d", tree.Len())
+	}
+
+	for _, k := range []int{5, 3, 8, 3, 1, 5} {
+		tree.Insert(k, k)
+	}
+
+	if tree.Len() != 4 {
+		t.Errorf("Len() = %d after inserting 4 distinct keys", tree.Len())
+	}
+
+	tree.Delete(3)
+	tree.Delete(42)
+
+	if tree.Len() != 3 {
+		t.Errorf("Len() = %d after deleting one of 4 keys", tree.Len())
+	}
+
+	tree.PopMin()
+	tree.PopMax()
+
+	if tree.Len() != 1 {
+		t.Errorf("Len() = %d after popping both ends", tree.Len())
+	}
+
+	tree.PopMin()
+	tree.PopMin()
+
+	if tree.Len() != 0 {
+		t.Errorf("Len() = %d after draining the tree", tree.Len())
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	tree := &llrb.Tree[string, int]{}
+
+	if _, _, ok := tree.Min(); ok {
+		t.Error("Min on an empty tree must report false")
+	}
+
+	if _, _, ok := tree.Max(); ok {
+		t.Error("Max on an empty tree must report false")
+	}
+
+	for i, k := range []string{"m", "c", "x", "a", "q"} {
+		tree.Insert(k, i)
+	}
+
+	if k, v, ok := tree.Min(); !ok || k != "a" || v != 3 {
+		t.Errorf("Min() = (%q, %d, %t), want (\"a\", 3, true)", k, v, ok)
+	}
+
+	if k, v, ok := tree.Max(); !ok || k != "x" || v != 2 {
+		t.Errorf("Max() = (%q, %d, %t), want (\"x\", 2, true)", k, v, ok)
+	}
+
+	if tree.Len() != 5 {
+		t.Error("Min and Max must not remove entries")
+	}
+}
+
+func TestDeleteMinMax(t *testing.T) {
+	tree := &llrb.Tree[int, int]{}
+	tree.DeleteMin()
+	tree.DeleteMax()
+
+	for k := range 10 {
+		tree.Insert(k, k)
+	}
+
+	tree.DeleteMin()
+	tree.DeleteMax()
+	tree.DeleteMin()
+
+	if got := tree.AppendKeysTo(nil); !slices.Equal(got, []int{2, 3, 4, 5, 6, 7, 8}) {
+		t.Errorf("keys = %v, want [2 3 4 5 6 7 8]", got)
+	}
+
+	if err := tree.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFloorCeiling(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{10, 20, 30}, []string{"a", "b", "c"})
+
+	for _, tt := range []struct {
+		key                  int
+		floor, ceiling       int
+		hasFloor, hasCeiling bool
+	}{
+		{key: 20, floor: 20, hasFloor: true, ceiling: 20, hasCeiling: true},
+		{key: 25, floor: 20, hasFloor: true, ceiling: 30, hasCeiling: true},
+		{key: 5, ceiling: 10, hasCeiling: true},
+		{key: 35, floor: 30, hasFloor: true},
+	} {
+		if k, _, ok := tree.Floor(tt.key); ok != tt.hasFloor || k != tt.floor {
+			t.Errorf("Floor(%d) = (%d, %t), want (%d, %t)", tt.key, k, ok, tt.floor, tt.hasFloor)
+		}
+
+		if k, _, ok := tree.Ceiling(tt.key); ok != tt.hasCeiling || k != tt.ceiling {
+			t.Errorf("Ceiling(%d) = (%d, %t), want (%d, %t)", tt.key, k, ok, tt.ceiling, tt.hasCeiling)
+		}
+	}
+
+	if _, v, _ := tree.Floor(29); v != "b" {
+		t.Errorf("Floor(29) value = %q, want \"b\"", v)
+	}
+}
+
+func TestPredecessorSuccessor(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{10, 20, 30}, []string{"a", "b", "c"})
+
+	// -- walk the tree forwards and backwards like a cursor.
+	var forward []int
+	for k, _, ok := tree.Min(); ok; k, _, ok = tree.Successor(k) {
+		forward = append(forward, k)
+	}
+
+	if !slices.Equal(forward, []int{10, 20, 30}) {
+		t.Errorf("walking with Successor visited %v", forward)
+	}
+
+	var backward []int
+	for k, _, ok := tree.Max(); ok; k, _, ok = tree.Predecessor(k) {
+		backward = append(backward, k)
+	}
+
+	if !slices.Equal(backward, []int{30, 20, 10}) {
+		t.Errorf("walking with Predecessor visited %v", backward)
+	}
+
+	if k, v, ok := tree.Predecessor(25); !ok || k != 20 || v != "b" {
+		t.Errorf("Predecessor(25) = (%d, %q, %t)", k, v, ok)
+	}
+
+	if k, _, ok := tree.Successor(25); !ok || k != 30 {
+		t.Errorf("Successor(25) = (%d, %t)", k, ok)
+	}
+
+	if _, _, ok := tree.Predecessor(10); ok {
+		t.Error("Predecessor of the minimum must report false")
+	}
+}
+
+func TestContains(t *testing.T) {
+	tree := llrb.FromSortedKVs([]string{"a", "c"}, [][1024]byte{{}, {}})
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true, "": false} {
+		if got := tree.Contains(key); got != want {
+			t.Errorf("Contains(%q) = %t, want %t", key, got, want)
+		}
+	}
+}
+
+func TestGetOrDefault(t *testing.T) {
+	tree := llrb.FromSortedKVs([]string{"retries", "timeout"}, []int{3, 0})
+
+	if got := tree.GetOrDefault("retries", 5); got != 3 {
+		t.Errorf("GetOrDefault(retries) = %d, want 3", got)
+	}
+
+	// -- a stored zero value is not replaced by the default.
+	if got := tree.GetOrDefault("timeout", 30); got != 0 {
+		t.Errorf("GetOrDefault(timeout) = %d, want 0", got)
+	}
+
+	if got := tree.GetOrDefault("backoff", 7); got != 7 {
+		t.Errorf("GetOrDefault(backoff) = %d, want 7", got)
+	}
+}
+
+func TestRankSelect(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{10, 20, 30, 40}, []string{"a", "b", "c", "d"})
+
+	for key, want := range map[int]int{5: 0, 10: 0, 15: 1, 30: 2, 40: 3, 45: 4} {
+		if got := tree.Rank(key); got != want {
+			t.Errorf("Rank(%d) = %d, want %d", key, got, want)
+		}
+	}
+
+	for i, want := range []int{10, 20, 30, 40} {
+		if k, _, ok := tree.Select(i); !ok || k != want {
+			t.Errorf("Select(%d) = (%d, %t), want (%d, true)", i, k, ok, want)
+		}
+	}
+
+	if _, _, ok := tree.Select(4); ok {
+		t.Error("Select(Len()) must report false")
+	}
+
+	tree.Delete(20)
+
+	if k, v, _ := tree.Select(1); k != 30 || v != "c" {
+		t.Errorf("Select(1) after deleting 20 = (%d, %q), want (30, \"c\")", k, v)
+	}
+}
+
+func TestDeleteAt(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{10, 20, 30, 40}, []string{"a", "b", "c", "d"})
+
+	if k, v, ok := tree.DeleteAt(2); !ok || k != 30 || v != "c" {
+		t.Errorf("DeleteAt(2) = (%d, %q, %t), want (30, \"c\", true)", k, v, ok)
+	}
+
+	for _, i := range []int{-1, 3} {
+		if _, _, ok := tree.DeleteAt(i); ok {
+			t.Errorf("DeleteAt(%d) on a tree of 3 entries must report false", i)
+		}
+	}
+
+	tree.DeleteAt(0)
+
+	if got := tree.AppendKeysTo(nil); !slices.Equal(got, []int{20, 40}) {
+		t.Errorf("keys = %v, want [20 40]", got)
+	}
+
+	if tree.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", tree.Len())
+	}
+}
+
+func TestCountRange(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{10, 20, 30, 40}, []string{"a", "b", "c", "d"})
+
+	for _, tc := range []struct {
+		lo, hi, want int
+	}{
+		{lo: 0, hi: 100, want: 4},
+		{lo: 10, hi: 40, want: 3},
+		{lo: 11, hi: 41, want: 3},
+		{lo: 20, hi: 20, want: 0},
+		{lo: 40, hi: 10, want: 0},
+		{lo: 50, hi: 60, want: 0},
+	} {
+		if got := tree.CountRange(tc.lo, tc.hi); got != tc.want {
+			t.Errorf("CountRange(%d, %d) = %d, want %d", tc.lo, tc.hi, got, tc.want)
+		}
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	tree := &llrb.Tree[int, int]{}
+
+	if _, _, ok := tree.Median(); ok {
+		t.Error("Median on an empty tree must report false")
+	}
+
+	for i := 1; i <= 100; i++ {
+		tree.Insert(i, -i)
+	}
+
+	for _, tc := range []struct {
+		q    float64
+		want int
+	}{
+		{q: 0, want: 1},
+		{q: 0.5, want: 50},
+		{q: 0.95, want: 95},
+		{q: 0.99, want: 99},
+		{q: 1, want: 100},
+	} {
+		if k, v, ok := tree.Quantile(tc.q); !ok || k != tc.want || v != -tc.want {
+			t.Errorf("Quantile(%v) = (%d, %d, %t), want (%d, %d, true)", tc.q, k, v, ok, tc.want, -tc.want)
+		}
+	}
+
+	for _, q := range []float64{-0.1, 1.1, math.NaN()} {
+		if _, _, ok := tree.Quantile(q); ok {
+			t.Errorf("Quantile(%v) must report false", q)
+		}
+	}
+
+	if k, _, _ := tree.Median(); k != 50 {
+		t.Errorf("Median() = %d, want 50", k)
+	}
+}
+
+func TestNearest(t *testing.T) {
+	tree := &llrb.Tree[uint, string]{}
+
+	if _, _, ok := llrb.Nearest(tree, 5); ok {
+		t.Error("Nearest on an empty tree must report false")
+	}
+
+	for _, k := range []uint{10, 20, 40} {
+		tree.Insert(k, fmt.Sprint(k))
+	}
+
+	for _, tc := range []struct {
+		key, want uint
+	}{
+		{key: 0, want: 10},
+		{key: 20, want: 20},
+		{key: 24, want: 20},
+		{key: 30, want: 20},
+		{key: 31, want: 40},
+		{key: 100, want: 40},
+	} {
+		if k, v, ok := llrb.Nearest(tree, tc.key); !ok || k != tc.want || v != fmt.Sprint(tc.want) {
+			t.Errorf("Nearest(%d) = (%d, %q, %t), want (%d, %q, true)", tc.key, k, v, ok, tc.want, fmt.Sprint(tc.want))
+		}
+	}
+}
+
+func TestNearestExtremeKeys(t *testing.T) {
+	int64s := llrb.FromSortedKVs([]int64{math.MinInt64, 10}, []struct{}{{}, {}})
+	for key, want := range map[int64]int64{0: 10, -10: 10, math.MinInt64 + 1: math.MinInt64, math.MaxInt64: 10} {
+		if k, _, _ := llrb.Nearest(int64s, key); k != want {
+			t.Errorf("int64 Nearest(%d) = %d, want %d", key, k, want)
+		}
+	}
+
+	int8s := llrb.FromSortedKVs([]int8{-128, 127}, []struct{}{{}, {}})
+	for key, want := range map[int8]int8{120: 127, 0: 127, -1: -128, -128: -128} {
+		if k, _, _ := llrb.Nearest(int8s, key); k != want {
+			t.Errorf("int8 Nearest(%d) = %d, want %d", key, k, want)
+		}
+	}
+
+	uint64s := llrb.FromSortedKVs([]uint64{0, math.MaxUint64}, []struct{}{{}, {}})
+	for key, want := range map[uint64]uint64{math.MaxUint64 / 2: 0, math.MaxUint64/2 + 1: math.MaxUint64} {
+		if k, _, _ := llrb.Nearest(uint64s, key); k != want {
+			t.Errorf("uint64 Nearest(%d) = %d, want %d", key, k, want)
+		}
+	}
+
+	float32s := llrb.FromSortedKVs([]float32{-math.MaxFloat32, 0.5, 2}, []struct{}{{}, {}, {}})
+	for key, want := range map[float32]float32{1.2: 0.5, 1.3: 2, -math.MaxFloat32 / 2: -math.MaxFloat32} {
+		if k, _, _ := llrb.Nearest(float32s, key); k != want {
+			t.Errorf("float32 Nearest(%g) = %g, want %g", key, k, want)
+		}
+	}
+
+	nans := llrb.FromSortedKVs([]float64{math.NaN(), 1, 2, 3}, []struct{}{{}, {}, {}, {}})
+	for key, want := range map[float64]float64{0.1: 1, math.Inf(-1): 1, 2.4: 2, 10: 3} {
+		if k, _, _ := llrb.Nearest(nans, key); k != want {
+			t.Errorf("Nearest(%g) next to a NaN key = %g, want %g", key, k, want)
+		}
+	}
+
+	if k, _, _ := llrb.Nearest(nans, math.NaN()); !math.IsNaN(k) {
+		t.Errorf("Nearest(NaN) = %g, want NaN", k)
+	}
+}
+
+func TestRandomKey(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	tree := &llrb.Tree[int, int]{}
+
+	if _, _, ok := tree.RandomKey(rng); ok {
+		t.Error("RandomKey on an empty tree must report false")
+	}
+
+	for i := range 4 {
+		tree.Insert(i, -i)
+	}
+
+	counts := make([]int, 4)
+
+	for range 4000 {
+		k, v, ok := tree.RandomKey(rng)
+		if !ok || v != -k {
+			t.Fatalf("RandomKey() = (%d, %d, %t)", k, v, ok)
+		}
+
+		counts[k]++
+	}
+
+	for k, n := range counts {
+		if n < 800 || n > 1200 {
+			t.Errorf("key %d drawn %d times out of 4000, want about 1000", k, n)
+		}
+	}
+}
+
+func TestGetRef(t *testing.T) {
+	tree := &llrb.Tree[int, []int]{}
+
+	if ref, ok := tree.GetRef(1); ok || ref != nil {
+		t.Errorf("GetRef on an empty tree = (%v, %t), want (nil, false)", ref, ok)
+	}
+
+	for k := range 10 {
+		tree.Insert(k, nil)
+	}
+
+	ref, ok := tree.GetRef(3)
+	if !ok {
+		t.Fatal("GetRef(3) must find the key")
+	}
+
+	// -- insertions and deletions of other keys, and the rotations they
+	// trigger, keep the pointer valid.
+	for k := 10; k < 100; k++ {
+		tree.Insert(k, nil)
+	}
+
+	for k := range 100 {
+		if k != 3 {
+			tree.Delete(k)
+		}
+	}
+
+	*ref = append(*ref, 42)
+
+	if v, _ := tree.Search(3); !slices.Equal(v, []int{42}) {
+		t.Errorf("Search(3) = %v after updating through GetRef, want [42]", v)
+	}
+}
+
+func TestAccessCache(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{1, 2, 3}, []string{"a", "b", "c"})
+	tree.SetAccessCache(true)
+
+	if v, ok := tree.Search(2); !ok || v != "b" {
+		t.Fatalf("Search(2) = (%q, %t), want (\"b\", true)", v, ok)
+	}
+
+	// -- overwriting keeps the cached node, which must see the new value.
+	tree.Insert(2, "B")
+
+	if v, ok := tree.Search(2); !ok || v != "B" {
+		t.Errorf("Search(2) = (%q, %t) after overwrite, want (\"B\", true)", v, ok)
+	}
+
+	// -- removing the cached entry must not leave it reachable.
+	tree.Delete(2)
+
+	if tree.Contains(2) {
+		t.Error("Contains(2) after Delete(2) must be false")
+	}
+
+	tree.Insert(2, "b2")
+	tree.Search(2)
+	tree.IntersectSeq(slices.Values([]int{1, 3}))
+
+	if _, ok := tree.Search(2); ok {
+		t.Error("Search(2) after IntersectSeq dropped it must report false")
+	}
+
+	tree.SetAccessCache(false)
+
+	if got := tree.GetOrDefault(3, "?"); got != "c" {
+		t.Errorf("GetOrDefault(3) = %q with the cache disabled, want \"c\"", got)
+	}
+}
+
+func TestNaNKeys(t *testing.T) {
+	// -- cmp.Compare orders NaN before every other float and equal to itself;
+	// every operation must agree with it.
+	nan := math.NaN()
+	tree := llrb.FromSortedKVs([]float64{1, 2, nan}, []string{"one", "two", "nan"})
+
+	if err := tree.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := tree.Search(nan); !ok || v != "nan" {
+		t.Errorf("Search(NaN) = (%q, %t), want (\"nan\", true)", v, ok)
+	}
+
+	if values, found := tree.MultiGet([]float64{nan, 2}); !found[0] || values[0] != "nan" || !found[1] {
+		t.Errorf("MultiGet([NaN 2]) = (%q, %v)", values, found)
+	}
+
+	if k, _, ok := tree.Successor(nan); !ok || k != 1 {
+		t.Errorf("Successor(NaN) = (%v, %t), want (1, true)", k, ok)
+	}
+
+	if k, _, ok := tree.Predecessor(1); !ok || !math.IsNaN(k) {
+		t.Errorf("Predecessor(1) = (%v, %t), want (NaN, true)", k, ok)
+	}
+
+	if n := tree.Within(llrb.Included(nan), llrb.Excluded(2.0)).Len(); n != 2 {
+		t.Errorf("Within([NaN, 2)).Len() = %d, want 2", n)
+	}
+
+	var keys []float64
+	for k := range tree.Range(llrb.Included(nan), llrb.Excluded(2.0)) {
+		keys = append(keys, k)
+	}
+
+	if len(keys) != 2 || !math.IsNaN(keys[0]) || keys[1] != 1 {
+		t.Errorf("Range([NaN, 2)) = %v, want [NaN 1]", keys)
+	}
+}
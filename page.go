@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/alexandremahdhaoui/llrb/internal"
+)
+
+// ------------------------------------------------------------------------------
+// -- PAGINATION
+//
+// Page walks a tree in ascending key order, limit entries at a time. Each page
+// comes with the Cursor to pass for the next one. Cursors only record the last
+// key returned, so they stay valid however the tree changes in between: the
+// next page resumes at the first key greater than that one. This holds for the
+// cursor of the last page too: it reports Done, yet paging from it later
+// returns the entries inserted past the end in the meantime.
+// ------------------------------------------------------------------------------
+
+// ErrInvalidCursor is wrapped by the errors returned when decoding a malformed
+// cursor.
+var ErrInvalidCursor = errors.New("llrb: invalid cursor")
+
+// Cursor is a position in the key order of a tree. The zero value points
+// before the smallest key. Cursors implement encoding.TextMarshaler and
+// encoding.TextUnmarshaler, producing opaque URL-safe tokens.
+type Cursor[K cmp.Ordered] struct {
+	after K
+	// positioned is set when after is meaningful; done is set once the
+	// cursor has reached the largest key, and only informs the caller.
+	positioned, done bool
+}
+
+// After returns a cursor pointing right after key, which does not need to be
+// in the tree.
+func After[K cmp.Ordered](key K) Cursor[K] {
+	return Cursor[K]{after: key, positioned: true}
+}
+
+// Done reports whether the page that returned c was the last one at the time.
+// Paging from a Done cursor still returns the keys inserted after it since.
+func (c Cursor[K]) Done() bool {
+	return c.done
+}
+
+// cursorToken is the serialized form of a Cursor.
+type cursorToken[K cmp.Ordered] struct {
+	After *K   `json:"a,omitempty"`
+	Done  bool `json:"d,omitempty"`
+}
+
+func (c Cursor[K]) MarshalText() ([]byte, error) {
+	token := cursorToken[K]{Done: c.done}
+	if c.positioned {
+		token.After = &c.after
+	}
+
+	b, err := json.Marshal(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.RawURLEncoding.AppendEncode(nil, b), nil
+}
+
+func (c *Cursor[K]) UnmarshalText(text []byte) error {
+	b, err := base64.RawURLEncoding.AppendDecode(nil, text)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	var token cursorToken[K]
+	if err := json.Unmarshal(b, &token); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	*c = Cursor[K]{done: token.Done}
+	if token.After != nil {
+		c.after, c.positioned = *token.After, true
+	}
+
+	return nil
+}
+
+// Page returns up to limit entries following after, in ascending key order,
+// and the cursor to resume from. The returned cursor is Done once the last
+// entry of the tree has been returned.
+func (t *Tree[K, V]) Page(after Cursor[K], limit int) ([]Entry[K, V], Cursor[K]) {
+	if limit <= 0 {
+		return nil, after
+	}
+
+	// -- collect one extra entry to learn whether another page follows.
+	page := make([]Entry[K, V], 0, min(limit, t.size)+1)
+
+	internal.InOrderRange(t.root,
+		func(key K) bool { return !after.positioned || cmp.Less(after.after, key) },
+		func(K) bool { return true },
+		func(n *internal.Node[K, V]) bool {
+			page = append(page, Entry[K, V]{Key: n.Key, Value: n.Value})
+			return len(page) <= limit
+		})
+
+	if len(page) <= limit {
+		// -- keep the position, so that keys inserted past the end are
+		// picked up when paging resumes from this cursor.
+		next := after
+		if len(page) > 0 {
+			next = After(page[len(page)-1].Key)
+		}
+
+		next.done = true
+
+		return page, next
+	}
+
+	page = page[:limit]
+
+	return page, After(page[limit-1].Key)
+}
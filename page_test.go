@@ -0,0 +1,113 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb_test
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb"
+)
+
+func TestPage(t *testing.T) {
+	tree := llrb.FromSortedKVs([]string{"a", "b", "c", "d", "e"}, []int{1, 2, 3, 4, 5})
+
+	var (
+		cursor llrb.Cursor[string]
+		pages  [][]string
+	)
+
+	for !cursor.Done() {
+		var page []llrb.Entry[string, int]
+		page, cursor = tree.Page(cursor, 2)
+
+		var keys []string
+		for _, e := range page {
+			keys = append(keys, e.Key)
+		}
+
+		pages = append(pages, keys)
+
+		// -- round-trip the cursor as a client of a paginated API would.
+		token, err := cursor.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cursor = llrb.Cursor[string]{}
+		if err := cursor.UnmarshalText(token); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(pages) == 1 {
+			// -- changes made between pages are picked up by the next ones.
+			tree.Delete("c")
+			tree.Insert("f", 6)
+		}
+	}
+
+	want := [][]string{{"a", "b"}, {"d", "e"}, {"f"}}
+	if !slices.EqualFunc(pages, want, slices.Equal) {
+		t.Errorf("pages = %v, want %v", pages, want)
+	}
+
+	if page, next := tree.Page(cursor, 2); len(page) != 0 || !next.Done() {
+		t.Errorf("paging from a done cursor = (%v, done: %t), want no entries and done", page, next.Done())
+	}
+
+	// -- keys inserted past the end are reachable from the done cursor.
+	tree.Insert("b2", 0)
+	tree.Insert("g", 7)
+
+	if page, next := tree.Page(cursor, 2); len(page) != 1 || page[0].Key != "g" || !next.Done() {
+		t.Errorf("Page(done cursor) after inserting g = (%v, done: %t), want [g] and done", page, next.Done())
+	}
+
+	tree.Delete("g")
+	tree.Delete("b2")
+
+	if page, next := tree.Page(llrb.After("d"), 10); len(page) != 2 || page[0].Key != "e" || !next.Done() {
+		t.Errorf("Page(After(d), 10) = (%v, done: %t), want [e f] and done", page, next.Done())
+	}
+}
+
+func TestPageEverything(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{1, 2, 3}, []string{"a", "b", "c"})
+
+	if page, next := tree.Page(llrb.Cursor[int]{}, math.MaxInt); len(page) != 3 || !next.Done() {
+		t.Errorf("Page(MaxInt) = (%v, done: %t), want every entry and done", page, next.Done())
+	}
+
+	empty := &llrb.Tree[int, string]{}
+	_, next := empty.Page(llrb.Cursor[int]{}, 10)
+
+	empty.Insert(1, "a")
+
+	if page, _ := empty.Page(next, 10); len(page) != 1 {
+		t.Errorf("Page(done cursor of an empty tree) = %v, want [1]", page)
+	}
+}
+
+func TestCursorUnmarshalErrors(t *testing.T) {
+	for _, token := range []string{"!!", "bm90IGpzb24"} {
+		var c llrb.Cursor[int]
+		if err := c.UnmarshalText([]byte(token)); !errors.Is(err, llrb.ErrInvalidCursor) {
+			t.Errorf("UnmarshalText(%q) = %v, want ErrInvalidCursor", token, err)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb
+
+import (
+	"cmp"
+	"context"
+	"iter"
+	"math/rand/v2"
+)
+
+// ------------------------------------------------------------------------------
+// -- READ-ONLY TREE
+//
+// ReadOnlyTree exposes the non-mutating methods of a Tree. It reflects later
+// changes made through the Tree it was obtained from, but gives its holders no
+// way to make such changes themselves.
+// ------------------------------------------------------------------------------
+
+type ReadOnlyTree[K cmp.Ordered, V any] struct {
+	tree *Tree[K, V]
+}
+
+// ReadOnly returns a read-only handle over the tree.
+func (t *Tree[K, V]) ReadOnly() ReadOnlyTree[K, V] {
+	return ReadOnlyTree[K, V]{tree: t}
+}
+
+func (r ReadOnlyTree[K, V]) Len() int {
+	return r.tree.Len()
+}
+
+func (r ReadOnlyTree[K, V]) Search(key K) (V, bool) {
+	return r.tree.Search(key)
+}
+
+func (r ReadOnlyTree[K, V]) GetOrDefault(key K, def V) V {
+	return r.tree.GetOrDefault(key, def)
+}
+
+func (r ReadOnlyTree[K, V]) Contains(key K) bool {
+	return r.tree.Contains(key)
+}
+
+func (r ReadOnlyTree[K, V]) Min() (K, V, bool) {
+	return r.tree.Min()
+}
+
+func (r ReadOnlyTree[K, V]) Max() (K, V, bool) {
+	return r.tree.Max()
+}
+
+func (r ReadOnlyTree[K, V]) Floor(key K) (K, V, bool) {
+	return r.tree.Floor(key)
+}
+
+func (r ReadOnlyTree[K, V]) Ceiling(key K) (K, V, bool) {
+	return r.tree.Ceiling(key)
+}
+
+func (r ReadOnlyTree[K, V]) Predecessor(key K) (K, V, bool) {
+	return r.tree.Predecessor(key)
+}
+
+func (r ReadOnlyTree[K, V]) Successor(key K) (K, V, bool) {
+	return r.tree.Successor(key)
+}
+
+func (r ReadOnlyTree[K, V]) Rank(key K) int {
+	return r.tree.Rank(key)
+}
+
+func (r ReadOnlyTree[K, V]) Select(i int) (K, V, bool) {
+	return r.tree.Select(i)
+}
+
+func (r ReadOnlyTree[K, V]) Quantile(q float64) (K, V, bool) {
+	return r.tree.Quantile(q)
+}
+
+func (r ReadOnlyTree[K, V]) Median() (K, V, bool) {
+	return r.tree.Median()
+}
+
+func (r ReadOnlyTree[K, V]) RandomKey(rng *rand.Rand) (K, V, bool) {
+	return r.tree.RandomKey(rng)
+}
+
+func (r ReadOnlyTree[K, V]) CountRange(lo, hi K) int {
+	return r.tree.CountRange(lo, hi)
+}
+
+func (r ReadOnlyTree[K, V]) AppendKeysTo(dst []K) []K {
+	return r.tree.AppendKeysTo(dst)
+}
+
+func (r ReadOnlyTree[K, V]) AppendEntriesTo(dst []Entry[K, V]) []Entry[K, V] {
+	return r.tree.AppendEntriesTo(dst)
+}
+
+func (r ReadOnlyTree[K, V]) MinN(k int) []Entry[K, V] {
+	return r.tree.MinN(k)
+}
+
+func (r ReadOnlyTree[K, V]) MaxN(k int) []Entry[K, V] {
+	return r.tree.MaxN(k)
+}
+
+func (r ReadOnlyTree[K, V]) Page(after Cursor[K], limit int) ([]Entry[K, V], Cursor[K]) {
+	return r.tree.Page(after, limit)
+}
+
+func (r ReadOnlyTree[K, V]) Range(lo, hi Bound[K]) iter.Seq2[K, V] {
+	return r.tree.Range(lo, hi)
+}
+
+func (r ReadOnlyTree[K, V]) All() iter.Seq2[K, V] {
+	return r.tree.All()
+}
+
+func (r ReadOnlyTree[K, V]) Take(n int) iter.Seq2[K, V] {
+	return r.tree.Take(n)
+}
+
+func (r ReadOnlyTree[K, V]) Drop(n int) iter.Seq2[K, V] {
+	return r.tree.Drop(n)
+}
+
+func (r ReadOnlyTree[K, V]) Chunks(n int) iter.Seq[[]Entry[K, V]] {
+	return r.tree.Chunks(n)
+}
+
+func (r ReadOnlyTree[K, V]) IterChan(ctx context.Context, buffer int) <-chan Entry[K, V] {
+	return r.tree.IterChan(ctx, buffer)
+}
+
+func (r ReadOnlyTree[K, V]) Freeze() *Frozen[K, V] {
+	return r.tree.Freeze()
+}
+
+// Partition is like Tree.Partition. The returned trees are independent copies
+// and may be mutated freely.
+func (r ReadOnlyTree[K, V]) Partition(pred func(key K, value V) bool) (matching, rest *Tree[K, V]) {
+	return r.tree.Partition(pred)
+}
+
+func (r ReadOnlyTree[K, V]) Validate() error {
+	return r.tree.Validate()
+}
+
+func (r ReadOnlyTree[K, V]) Shape() Shape {
+	return r.tree.Shape()
+}
+
+// CopyRange is like Tree.CopyRange: it copies entries out of the read-only
+// tree into dst.
+func (r ReadOnlyTree[K, V]) CopyRange(dst *Tree[K, V], lo, hi K) int {
+	return r.tree.CopyRange(dst, lo, hi)
+}
+
+func (r ReadOnlyTree[K, V]) ContainsAll(seq iter.Seq[K]) bool {
+	return r.tree.ContainsAll(seq)
+}
+
+func (r ReadOnlyTree[K, V]) ScanAround(key K, n int) []Entry[K, V] {
+	return r.tree.ScanAround(key, n)
+}
+
+func (r ReadOnlyTree[K, V]) MultiGet(keys []K) (values []V, found []bool) {
+	return r.tree.MultiGet(keys)
+}
+
+func (r ReadOnlyTree[K, V]) WalkDepth(maxDepth int, fn func(depth int, key K, value V) bool) {
+	r.tree.WalkDepth(maxDepth, fn)
+}
+
+func (r ReadOnlyTree[K, V]) LevelOrder() iter.Seq2[int, Entry[K, V]] {
+	return r.tree.LevelOrder()
+}
+
+func (r ReadOnlyTree[K, V]) Explain(key K) []Step[K] {
+	return r.tree.Explain(key)
+}
+
+func (r ReadOnlyTree[K, V]) Dump() string {
+	return r.tree.Dump()
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb"
+)
+
+func TestReadOnly(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{1, 2}, []string{"a", "b"})
+	ro := tree.ReadOnly()
+
+	if v, ok := ro.Search(2); !ok || v != "b" {
+		t.Errorf("Search(2) = (%q, %t), want (\"b\", true)", v, ok)
+	}
+
+	tree.Insert(3, "c")
+
+	if got := ro.AppendKeysTo(nil); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("read-only handle does not reflect the tree: %v", got)
+	}
+
+	if f := ro.Freeze(); f.Len() != 3 {
+		t.Errorf("Freeze().Len() = %d, want 3", f.Len())
+	}
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/alexandremahdhaoui/llrb/internal"
+)
+
+// ------------------------------------------------------------------------------
+// -- VIEW
+//
+// A View is a live window over a key range of a Tree, delimited by two Bounds.
+// It holds no data of its own: reads and writes go straight to the backing
+// tree, restricted to keys inside the range.
+// ------------------------------------------------------------------------------
+
+type View[K cmp.Ordered, V any] struct {
+	tree *Tree[K, V]
+
+	lo, hi Bound[K]
+}
+
+// SubMap returns a view over the keys k such that lo <= k < hi.
+func (t *Tree[K, V]) SubMap(lo, hi K) *View[K, V] {
+	return t.Within(Included(lo), Excluded(hi))
+}
+
+// HeadMap returns a view over the keys strictly less than hi.
+func (t *Tree[K, V]) HeadMap(hi K) *View[K, V] {
+	return t.Within(Unbounded[K](), Excluded(hi))
+}
+
+// TailMap returns a view over the keys greater than or equal to lo.
+func (t *Tree[K, V]) TailMap(lo K) *View[K, V] {
+	return t.Within(Included(lo), Unbounded[K]())
+}
+
+// InRange reports whether key lies within the range of the view.
+func (v *View[K, V]) InRange(key K) bool {
+	return v.lo.admitsAbove(key) && v.hi.admitsBelow(key)
+}
+
+// Len returns the number of entries within the view in O(log n), using the
+// ranks of its bounds in the backing tree.
+func (v *View[K, V]) Len() int {
+	var below, upTo int
+
+	switch key, _ := v.lo.Key(); v.lo.kind {
+	case included:
+		below = v.tree.countUpTo(key, false)
+	case excluded:
+		below = v.tree.countUpTo(key, true)
+	}
+
+	switch key, _ := v.hi.Key(); v.hi.kind {
+	case included:
+		upTo = v.tree.countUpTo(key, true)
+	case excluded:
+		upTo = v.tree.countUpTo(key, false)
+	default:
+		upTo = v.tree.Len()
+	}
+
+	return max(upTo-below, 0)
+}
+
+// All returns the entries within the view in ascending key order. The backing
+// tree must not be mutated while the sequence is being consumed.
+func (v *View[K, V]) All() iter.Seq2[K, V] {
+	return v.tree.Range(v.lo, v.hi)
+}
+
+func (v *View[K, V]) Search(key K) (V, bool) {
+	if !v.InRange(key) {
+		var zeroVal V
+		return zeroVal, false
+	}
+
+	return v.tree.Search(key)
+}
+
+func (v *View[K, V]) Contains(key K) bool {
+	return v.InRange(key) && v.tree.Contains(key)
+}
+
+// Insert inserts key into the backing tree. It returns false and does nothing
+// if key lies outside the range of the view.
+func (v *View[K, V]) Insert(key K, value V) bool {
+	if !v.InRange(key) {
+		return false
+	}
+
+	v.tree.Insert(key, value)
+
+	return true
+}
+
+// Delete removes key from the backing tree. Keys outside the range of the view
+// are left untouched.
+func (v *View[K, V]) Delete(key K) {
+	if v.InRange(key) {
+		v.tree.Delete(key)
+	}
+}
+
+// AppendKeysTo appends the keys within the view to dst in ascending order and
+// returns the extended slice.
+func (v *View[K, V]) AppendKeysTo(dst []K) []K {
+	internal.InOrderRange(v.tree.root, v.lo.admitsAbove, v.hi.admitsBelow, func(n *internal.Node[K, V]) bool {
+		dst = append(dst, n.Key)
+		return true
+	})
+
+	return dst
+}
+
+// AppendEntriesTo appends the entries within the view to dst in ascending key
+// order and returns the extended slice.
+func (v *View[K, V]) AppendEntriesTo(dst []Entry[K, V]) []Entry[K, V] {
+	internal.InOrderRange(v.tree.root, v.lo.admitsAbove, v.hi.admitsBelow, func(n *internal.Node[K, V]) bool {
+		dst = append(dst, Entry[K, V]{Key: n.Key, Value: n.Value})
+		return true
+	})
+
+	return dst
+}
+
+// countUpTo returns the number of keys less than key, or less than or equal to
+// key if inclusive is set.
+func (t *Tree[K, V]) countUpTo(key K, inclusive bool) int {
+	n := t.Rank(key)
+	if inclusive && t.Contains(key) {
+		n++
+	}
+
+	return n
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb"
+)
+
+func TestView(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{1, 2, 3, 4, 5, 6}, []string{"a", "b", "c", "d", "e", "f"})
+
+	for _, tt := range []struct {
+		name string
+		view *llrb.View[int, string]
+		want []int
+	}{
+		{name: "SubMap", view: tree.SubMap(2, 5), want: []int{2, 3, 4}},
+		{name: "HeadMap", view: tree.HeadMap(3), want: []int{1, 2}},
+		{name: "TailMap", view: tree.TailMap(5), want: []int{5, 6}},
+		{name: "empty SubMap", view: tree.SubMap(4, 4), want: nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.view.AppendKeysTo(nil); !slices.Equal(got, tt.want) {
+				t.Errorf("keys = %v, want %v", got, tt.want)
+			}
+
+			if got := tt.view.Len(); got != len(tt.want) {
+				t.Errorf("Len() = %d, want %d", got, len(tt.want))
+			}
+
+			var all []int
+			for k, v := range tt.view.All() {
+				if v != string(rune('a'+k-1)) {
+					t.Errorf("All() yielded (%d, %q)", k, v)
+				}
+
+				all = append(all, k)
+			}
+
+			if !slices.Equal(all, tt.want) {
+				t.Errorf("All() keys = %v, want %v", all, tt.want)
+			}
+
+			for k := 0; k <= 7; k++ {
+				_, ok := tt.view.Search(k)
+				if want := slices.Contains(tt.want, k); ok != want {
+					t.Errorf("Search(%d) found=%t, want %t", k, ok, want)
+				}
+
+				if ok != tt.view.Contains(k) {
+					t.Errorf("Contains(%d) disagrees with Search", k)
+				}
+			}
+		})
+	}
+}
+
+func TestViewWrites(t *testing.T) {
+	tree := llrb.FromSortedKVs([]int{1, 2, 3}, []string{"a", "b", "c"})
+	view := tree.SubMap(2, 10)
+
+	if view.Insert(0, "z") {
+		t.Error("Insert outside the view must be rejected")
+	}
+
+	if !view.Insert(7, "g") {
+		t.Error("Insert inside the view must succeed")
+	}
+
+	view.Delete(1)
+	view.Delete(2)
+
+	if got := tree.AppendKeysTo(nil); !slices.Equal(got, []int{1, 3, 7}) {
+		t.Errorf("backing tree keys = %v, want [1 3 7]", got)
+	}
+
+	tree.Insert(5, "e")
+
+	if got := view.AppendEntriesTo(nil); !slices.Equal(got, []llrb.Entry[int, string]{
+		{Key: 3, Value: "c"}, {Key: 5, Value: "e"}, {Key: 7, Value: "g"},
+	}) {
+		t.Errorf("view does not reflect the backing tree: %v", got)
+	}
+}
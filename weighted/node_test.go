@@ -0,0 +1,129 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package weighted
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb"
+)
+
+// TestBalance checks the LLRB invariants and the total weights of the nodes
+// after random insertions, overwrites and deletions. The same operations are
+// applied to an llrb.Tree, whose balancing this package mirrors: both trees
+// must keep the exact same shape at every step.
+func TestBalance(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	tree := New[int](func(w int) float64 { return float64(w) })
+	plain := &llrb.Tree[int, int]{}
+
+	for i := range 2000 {
+		k := rng.IntN(300)
+		if i%3 == 0 {
+			tree.Delete(k)
+			plain.Delete(k)
+		} else {
+			v := rng.IntN(10)
+			tree.Insert(k, v)
+			plain.Insert(k, v)
+		}
+
+		if tree.root.isRed() {
+			t.Fatalf("step %d: red root", i)
+		}
+
+		if n, _ := checkNode(t, tree.root); n != tree.Len() {
+			t.Fatalf("step %d: tree holds %d nodes, Len() = %d", i, n, tree.Len())
+		}
+
+		if err := plain.Validate(); err != nil {
+			t.Fatalf("step %d: llrb.Tree: %v", i, err)
+		}
+
+		if got, want := dump(tree.root), plain.Dump(); got != want {
+			t.Fatalf("step %d: shape diverged from llrb.Tree:\n%s\nwant:\n%s", i, got, want)
+		}
+	}
+}
+
+// dump formats the subtree like llrb.Tree.Dump.
+func dump(n *node[int, int]) string {
+	var b strings.Builder
+
+	var walk func(n *node[int, int], depth int)
+	walk = func(n *node[int, int], depth int) {
+		b.WriteString(strings.Repeat("  ", depth))
+
+		if n == nil {
+			b.WriteString("-\n")
+			return
+		}
+
+		color := "B"
+		if n.isRed() {
+			color = "R"
+		}
+
+		fmt.Fprintf(&b, "%s %v\n", color, n.key)
+
+		if n.children[left] != nil || n.children[right] != nil {
+			walk(n.children[left], depth+1)
+			walk(n.children[right], depth+1)
+		}
+	}
+
+	walk(n, 0)
+
+	return b.String()
+}
+
+// checkNode returns the number of nodes and the black height of the subtree.
+func checkNode(t *testing.T, n *node[int, int]) (int, int) {
+	t.Helper()
+
+	if n == nil {
+		return 0, 0
+	}
+
+	l, r := n.children[left], n.children[right]
+
+	switch {
+	case r.isRed():
+		t.Fatalf("key %v: red right link", n.key)
+	case n.isRed() && l.isRed():
+		t.Fatalf("key %v: two red links in a row", n.key)
+	case l != nil && l.key >= n.key, r != nil && r.key <= n.key:
+		t.Fatalf("key %v: keys out of order", n.key)
+	case n.total != n.weight+l.totalWeight()+r.totalWeight():
+		t.Fatalf("key %v: total weight %v out of date", n.key, n.total)
+	}
+
+	ln, lh := checkNode(t, l)
+	rn, rh := checkNode(t, r)
+
+	if lh != rh {
+		t.Fatalf("key %v: black heights %d and %d differ", n.key, lh, rh)
+	}
+
+	if !n.isRed() {
+		lh++
+	}
+
+	return ln + rn + 1, lh
+}
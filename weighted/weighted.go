@@ -0,0 +1,353 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package weighted provides Tree, an ordered map whose keys can be picked at
+// random proportionally to a weight derived from their values.
+package weighted
+
+import (
+	"cmp"
+	"math"
+)
+
+// ------------------------------------------------------------------------------
+// -- WEIGHTED TREE
+//
+// A Tree is a left-leaning red-black tree whose nodes also record the total
+// weight of their subtree. Weighted selection then descends the tree once,
+// skipping whole subtrees by their weight, the same way Select skips them by
+// their size in an llrb.Tree.
+//
+// The tree has its own nodes, rather than sharing those of llrb.Tree, so that
+// plain trees do not pay for the weight bookkeeping.
+// ------------------------------------------------------------------------------
+
+type Tree[K cmp.Ordered, V any] struct {
+	root *node[K, V]
+	size int
+
+	// weight derives the weight of an entry from its value.
+	weight func(V) float64
+}
+
+// New returns an empty tree weighing each entry with weight(value). Weights
+// must be finite and non-negative; entries weighing zero are never selected.
+func New[K cmp.Ordered, V any](weight func(V) float64) *Tree[K, V] {
+	return &Tree[K, V]{weight: weight}
+}
+
+// Len returns the number of entries in the tree.
+func (t *Tree[K, V]) Len() int {
+	return t.size
+}
+
+// TotalWeight returns the sum of the weights of all entries.
+func (t *Tree[K, V]) TotalWeight() float64 {
+	return t.root.totalWeight()
+}
+
+func (t *Tree[K, V]) Search(key K) (V, bool) {
+	for n := t.root; n != nil; {
+		switch c := cmp.Compare(key, n.key); {
+		case c < 0:
+			n = n.children[left]
+		case c > 0:
+			n = n.children[right]
+		default:
+			return n.value, true
+		}
+	}
+
+	var zeroVal V
+	return zeroVal, false
+}
+
+// Insert stores value under key, overwriting and reweighing any previous
+// entry. It panics if the weight of value is negative, infinite or NaN.
+func (t *Tree[K, V]) Insert(key K, value V) {
+	w := t.weight(value)
+	if !(w >= 0) || math.IsInf(w, 1) {
+		panic("llrb/weighted: entry weight must be finite and non-negative")
+	}
+
+	var inserted bool
+	t.root, inserted = insert(t.root, key, value, w)
+	t.root.isBlack = true
+
+	if inserted {
+		t.size++
+	}
+}
+
+// Delete removes key from the tree. Deleting a missing key is a no-op.
+func (t *Tree[K, V]) Delete(key K) {
+	var deleted bool
+	t.root, deleted = remove(t.root, key)
+
+	if t.root != nil {
+		t.root.isBlack = true
+	}
+
+	if deleted {
+		t.size--
+	}
+}
+
+// SelectByWeight maps r, a fraction in [0, 1), to an entry such that drawing r
+// uniformly picks each entry with probability weight/TotalWeight(). Entries
+// are laid out in key order, so r also grows with the key it selects. It runs
+// in O(log n). r is clamped to that range: r <= 0 and NaN select the first
+// entry with a positive weight, r >= 1 the last one. The boolean is false if
+// the total weight is zero.
+func (t *Tree[K, V]) SelectByWeight(r float64) (K, V, bool) {
+	// -- written so that NaN fails the test too; r >= 1 falls through to last.
+	if !(r > 0) {
+		r = 0
+	}
+
+	w := r * t.TotalWeight()
+
+	// -- last is the answer should rounding push w past the total weight.
+	var last *node[K, V]
+
+	for n := t.root; n != nil; {
+		l := n.children[left].totalWeight()
+		if w < l {
+			n = n.children[left]
+			continue
+		}
+
+		w -= l
+
+		if n.weight > 0 {
+			if w < n.weight {
+				return n.key, n.value, true
+			}
+
+			last = n
+		}
+
+		w -= n.weight
+		n = n.children[right]
+	}
+
+	if last == nil {
+		var zeroKey K
+		var zeroVal V
+		return zeroKey, zeroVal, false
+	}
+
+	return last.key, last.value, true
+}
+
+// ------------------------------------------------------------------------------
+// -- NODE
+//
+// The balancing below follows internal/llrb.go step by step; only the
+// bookkeeping differs, with total weights in place of subtree sizes. TestBalance
+// replays random operations on both and fails as soon as their shapes differ.
+// ------------------------------------------------------------------------------
+
+type direction int
+
+const (
+	left direction = iota
+	right
+)
+
+type node[K cmp.Ordered, V any] struct {
+	key   K
+	value V
+
+	children [2]*node[K, V]
+	isBlack  bool
+	// weight is the weight of this entry; total sums it over the subtree.
+	weight, total float64
+}
+
+func (n *node[K, V]) totalWeight() float64 {
+	if n == nil {
+		return 0
+	}
+
+	return n.total
+}
+
+func (n *node[K, V]) isRed() bool {
+	return n != nil && !n.isBlack
+}
+
+// update recomputes the total weight of n from those of its children.
+func (n *node[K, V]) update() {
+	n.total = n.weight + n.children[left].totalWeight() + n.children[right].totalWeight()
+}
+
+func insert[K cmp.Ordered, V any](root *node[K, V], key K, value V, weight float64) (*node[K, V], bool) {
+	if root == nil {
+		return &node[K, V]{key: key, value: value, weight: weight, total: weight}, true
+	}
+
+	var inserted bool
+
+	switch c := cmp.Compare(key, root.key); {
+	case c < 0:
+		root.children[left], inserted = insert(root.children[left], key, value, weight)
+	case c > 0:
+		root.children[right], inserted = insert(root.children[right], key, value, weight)
+	default:
+		root.value, root.weight = value, weight
+	}
+
+	return fixUp(root), inserted
+}
+
+func remove[K cmp.Ordered, V any](root *node[K, V], key K) (*node[K, V], bool) {
+	if root == nil {
+		return nil, false
+	}
+
+	var deleted bool
+
+	// -- c is the comparison of key against the current root of the subtree,
+	// refreshed whenever a rotation replaces it.
+	c := cmp.Compare(key, root.key)
+
+	if c < 0 {
+		if root.children[left] == nil {
+			return root, false
+		}
+
+		if !root.children[left].isRed() && !root.children[left].children[left].isRed() {
+			root = moveRedLeft(root)
+		}
+
+		root.children[left], deleted = remove(root.children[left], key)
+
+		return fixUp(root), deleted
+	}
+
+	if root.children[left].isRed() {
+		root = rotate(root, right)
+		c = cmp.Compare(key, root.key)
+	}
+
+	if root.children[right] == nil {
+		if c == 0 {
+			return nil, true
+		}
+
+		return root, false
+	}
+
+	if !root.children[right].isRed() && !root.children[right].children[left].isRed() {
+		root = moveRedRight(root)
+		c = cmp.Compare(key, root.key)
+	}
+
+	if c == 0 {
+		r, successor := popMin(root.children[right])
+		successor.children = [2]*node[K, V]{root.children[left], r}
+		successor.isBlack = root.isBlack
+
+		return fixUp(successor), true
+	}
+
+	root.children[right], deleted = remove(root.children[right], key)
+
+	return fixUp(root), deleted
+}
+
+func popMin[K cmp.Ordered, V any](root *node[K, V]) (*node[K, V], *node[K, V]) {
+	if root.children[left] == nil {
+		return nil, root
+	}
+
+	if !root.children[left].isRed() && !root.children[left].children[left].isRed() {
+		root = moveRedLeft(root)
+	}
+
+	var removed *node[K, V]
+	root.children[left], removed = popMin(root.children[left])
+
+	return fixUp(root), removed
+}
+
+func rotate[K cmp.Ordered, V any](root *node[K, V], d direction) *node[K, V] {
+	x := root.children[1-d]
+	root.children[1-d] = x.children[d]
+	x.children[d] = root
+
+	x.isBlack = root.isBlack
+	root.isBlack = false
+
+	// -- the pivot now roots the same set of nodes root did.
+	x.total = root.total
+	root.update()
+
+	return x
+}
+
+func fixUp[K cmp.Ordered, V any](root *node[K, V]) *node[K, V] {
+	root.update()
+
+	if root.children[right].isRed() {
+		root = rotate(root, left)
+	}
+
+	if root.children[left].isRed() && root.children[left].children[left].isRed() {
+		root = rotate(root, right)
+	}
+
+	if root.children[left].isRed() && root.children[right].isRed() {
+		flipColor(root)
+	}
+
+	return root
+}
+
+func flipColor[K cmp.Ordered, V any](n *node[K, V]) {
+	n.isBlack = !n.isBlack
+
+	for _, child := range n.children {
+		if child != nil {
+			child.isBlack = !child.isBlack
+		}
+	}
+}
+
+func moveRedLeft[K cmp.Ordered, V any](root *node[K, V]) *node[K, V] {
+	flipColor(root)
+
+	if root.children[right].children[left].isRed() {
+		root.children[right] = rotate(root.children[right], right)
+		root = rotate(root, left)
+
+		flipColor(root)
+	}
+
+	return root
+}
+
+func moveRedRight[K cmp.Ordered, V any](root *node[K, V]) *node[K, V] {
+	flipColor(root)
+
+	if root.children[left].children[left].isRed() {
+		root = rotate(root, right)
+
+		flipColor(root)
+	}
+
+	return root
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package weighted_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb/weighted"
+)
+
+func TestSelectByWeight(t *testing.T) {
+	tree := weighted.New[string](func(w int) float64 { return float64(w) })
+
+	if _, _, ok := tree.SelectByWeight(0.5); ok {
+		t.Error("SelectByWeight on an empty tree must report false")
+	}
+
+	tree.Insert("a", 1)
+	tree.Insert("b", 0)
+	tree.Insert("c", 5)
+	tree.Insert("d", 4)
+	tree.Insert("c", 3) // -- reweighing an existing entry.
+	tree.Insert("e", 9)
+	tree.Delete("e")
+
+	if tree.Len() != 4 || tree.TotalWeight() != 8 {
+		t.Fatalf("Len() = %d, TotalWeight() = %v, want 4 and 8", tree.Len(), tree.TotalWeight())
+	}
+
+	for _, tc := range []struct {
+		r    float64
+		want string
+	}{
+		{r: 0, want: "a"},
+		{r: 0.124, want: "a"},
+		{r: 0.125, want: "c"},
+		{r: 0.49, want: "c"},
+		{r: 0.5, want: "d"},
+		{r: 0.999, want: "d"},
+		// -- out of range: clamped to the first and last weighted entries.
+		{r: -0.1, want: "a"},
+		{r: math.Inf(-1), want: "a"},
+		{r: math.NaN(), want: "a"},
+		{r: 1, want: "d"},
+		{r: 42, want: "d"},
+		{r: math.Inf(1), want: "d"},
+	} {
+		if k, _, ok := tree.SelectByWeight(tc.r); !ok || k != tc.want {
+			t.Errorf("SelectByWeight(%v) = (%q, %t), want (%q, true)", tc.r, k, ok, tc.want)
+		}
+	}
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	counts := map[string]int{}
+
+	for range 8000 {
+		k, _, _ := tree.SelectByWeight(rng.Float64())
+		counts[k]++
+	}
+
+	for k, want := range map[string]int{"a": 1000, "b": 0, "c": 3000, "d": 4000} {
+		if got := counts[k]; got < want*9/10 || got > want*11/10 {
+			t.Errorf("%q drawn %d times out of 8000, want about %d", k, got, want)
+		}
+	}
+}
+
+func TestInsertPanicsOnNegativeWeight(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Insert with a negative weight must panic")
+		}
+	}()
+
+	weighted.New[int](func(w float64) float64 { return w }).Insert(1, -1)
+}
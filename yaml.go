@@ -0,0 +1,51 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb
+
+// ------------------------------------------------------------------------------
+// -- YAML
+//
+// Tree implements the Marshaler and (v2-style) Unmarshaler interfaces of
+// gopkg.in/yaml.v2 and gopkg.in/yaml.v3 by signature only, so the package
+// does not depend on either. A tree is encoded as a sequence of key/value
+// mappings in ascending key order:
+//
+//	- key: a
+//	  value: 1
+//	- key: b
+//	  value: 2
+// ------------------------------------------------------------------------------
+
+// MarshalYAML returns the entries of the tree in ascending key order.
+func (t *Tree[K, V]) MarshalYAML() (any, error) {
+	return t.AppendEntriesTo([]Entry[K, V]{}), nil
+}
+
+// UnmarshalYAML replaces the content of the tree with the decoded entries.
+// When a key appears more than once, the last value wins.
+func (t *Tree[K, V]) UnmarshalYAML(unmarshal func(any) error) error {
+	var entries []Entry[K, V]
+	if err := unmarshal(&entries); err != nil {
+		return err
+	}
+
+	t.replace(nil, 0)
+	for _, e := range entries {
+		t.Insert(e.Key, e.Value)
+	}
+
+	return nil
+}
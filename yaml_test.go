@@ -0,0 +1,74 @@
+/*
+ * Copyright 2025 Alexandre Mahdhaoui
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llrb_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/alexandremahdhaoui/llrb"
+)
+
+func TestMarshalYAML(t *testing.T) {
+	tree := llrb.FromSortedKVs([]string{"b", "a"}, []int{2, 1})
+
+	out, err := tree.MarshalYAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []llrb.Entry[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}}
+	if got, ok := out.([]llrb.Entry[string, int]); !ok || !slices.Equal(got, want) {
+		t.Errorf("MarshalYAML() = %#v, want %v", out, want)
+	}
+
+	if out, _ := (&llrb.Tree[string, int]{}).MarshalYAML(); out.([]llrb.Entry[string, int]) == nil {
+		t.Error("an empty tree must marshal to an empty sequence, not null")
+	}
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	tree := llrb.FromSortedKVs([]string{"stale"}, []int{0})
+
+	// -- stands in for the decoder, which fills the target from the document.
+	decode := func(target any) error {
+		*target.(*[]llrb.Entry[string, int]) = []llrb.Entry[string, int]{
+			{Key: "b", Value: 2}, {Key: "a", Value: 1}, {Key: "b", Value: 3},
+		}
+
+		return nil
+	}
+
+	if err := tree.UnmarshalYAML(decode); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := tree.AppendEntriesTo(nil); !slices.Equal(got, []llrb.Entry[string, int]{
+		{Key: "a", Value: 1}, {Key: "b", Value: 3},
+	}) {
+		t.Errorf("entries = %v", got)
+	}
+
+	errDecode := errors.New("boom")
+	if err := tree.UnmarshalYAML(func(any) error { return errDecode }); !errors.Is(err, errDecode) {
+		t.Errorf("UnmarshalYAML() = %v, want the decoder error", err)
+	}
+
+	if got := tree.AppendKeysTo(nil); len(got) != 2 {
+		t.Error("a failed decode must leave the tree untouched")
+	}
+}